@@ -0,0 +1,27 @@
+// Package cbor registers a decoder for application/cbor bodies on
+// xr.PickerDefault when imported
+//
+//	import _ "github.com/gregoryv/xr/adapt/cbor"
+package cbor
+
+import (
+	"io"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/gregoryv/xr"
+)
+
+func init() {
+	Register(xr.PickerDefault)
+}
+
+// Register decoder for application/cbor on the given picker.
+func Register(p *xr.Picker) {
+	p.Register("application/cbor", Decoder)
+}
+
+// Decoder returns a CBOR decoder of r. Field tags cbor are used, or
+// json if missing.
+func Decoder(r io.Reader) xr.Decoder {
+	return cbor.NewDecoder(r)
+}
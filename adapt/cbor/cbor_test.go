@@ -0,0 +1,27 @@
+package cbor
+
+import (
+	"bytes"
+	"fmt"
+	"net/http/httptest"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/gregoryv/xr"
+)
+
+func Example() {
+	type Reading struct {
+		Device string  `header:"x-device"`
+		Temp   float64 `json:"temp"`
+	}
+	data, _ := cbor.Marshal(map[string]any{"temp": 21.5})
+	r := httptest.NewRequest("POST", "/readings", bytes.NewReader(data))
+	r.Header.Set("content-type", "application/cbor")
+	r.Header.Set("x-device", "sensor-1")
+
+	var x Reading
+	_ = xr.Pick(&x, r)
+	fmt.Println(x.Device, x.Temp)
+	// output:
+	// sensor-1 21.5
+}
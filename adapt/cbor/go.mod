@@ -0,0 +1,10 @@
+module github.com/gregoryv/xr/adapt/cbor
+
+go 1.22
+
+require (
+	github.com/fxamacker/cbor/v2 v2.9.1
+	github.com/gregoryv/xr v0.0.0-20261016183929-c762613f6805
+)
+
+require github.com/x448/float16 v0.8.4 // indirect
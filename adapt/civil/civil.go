@@ -0,0 +1,22 @@
+// Package civil registers setters for [civil.Date], [civil.Time]
+// and [civil.DateTime] fields on xr.PickerDefault when imported
+//
+//	import _ "github.com/gregoryv/xr/adapt/civil"
+package civil
+
+import (
+	"cloud.google.com/go/civil"
+	"github.com/gregoryv/xr"
+)
+
+func init() {
+	Register(xr.PickerDefault)
+}
+
+// Register setters for civil types on the given picker. Panics if
+// already registered.
+func Register(p *xr.Picker) {
+	xr.UseSetterFor(p, civil.ParseDate)
+	xr.UseSetterFor(p, civil.ParseTime)
+	xr.UseSetterFor(p, civil.ParseDateTime)
+}
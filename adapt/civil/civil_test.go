@@ -0,0 +1,28 @@
+package civil
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"cloud.google.com/go/civil"
+	"github.com/gregoryv/xr"
+)
+
+func Example() {
+	var x struct {
+		Day  civil.Date     `query:"day"`
+		At   civil.Time     `query:"at"`
+		When civil.DateTime `query:"when"`
+	}
+	u := "/?day=2024-09-09&at=13:30:00&when=2024-09-09T13:30:00"
+	r := httptest.NewRequest("GET", u, http.NoBody)
+	_ = xr.Pick(&x, r)
+	fmt.Println(x.Day)
+	fmt.Println(x.At.Hour)
+	fmt.Println(x.When.Date.Month)
+	// output:
+	// 2024-09-09
+	// 13
+	// September
+}
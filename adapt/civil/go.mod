@@ -0,0 +1,8 @@
+module github.com/gregoryv/xr/adapt/civil
+
+go 1.22
+
+require (
+	cloud.google.com/go v0.115.0
+	github.com/gregoryv/xr v0.0.0-20261016183929-c762613f6805
+)
@@ -0,0 +1,20 @@
+// Package decimal registers a setter for [decimal.Decimal] fields on
+// xr.PickerDefault when imported
+//
+//	import _ "github.com/gregoryv/xr/adapt/decimal"
+package decimal
+
+import (
+	"github.com/gregoryv/xr"
+	"github.com/shopspring/decimal"
+)
+
+func init() {
+	Register(xr.PickerDefault)
+}
+
+// Register setter for decimal.Decimal fields on the given
+// picker. Panics if already registered.
+func Register(p *xr.Picker) {
+	xr.UseSetterFor(p, decimal.NewFromString)
+}
@@ -0,0 +1,34 @@
+package decimal
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gregoryv/xr"
+	"github.com/shopspring/decimal"
+)
+
+func Example() {
+	var x struct {
+		Amount decimal.Decimal `query:"amount"`
+	}
+	r := httptest.NewRequest("GET", "/?amount=10.25", http.NoBody)
+	_ = xr.Pick(&x, r)
+	fmt.Println(x.Amount.Mul(decimal.NewFromInt(3)))
+	// output:
+	// 30.75
+}
+
+func ExampleRegister() {
+	p := xr.NewPicker()
+	Register(p)
+
+	var x struct {
+		Amount decimal.Decimal `query:"amount"`
+	}
+	r := httptest.NewRequest("GET", "/?amount=ten", http.NoBody)
+	fmt.Println(p.Pick(&x, r) != nil)
+	// output:
+	// true
+}
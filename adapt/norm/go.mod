@@ -0,0 +1,8 @@
+module github.com/gregoryv/xr/adapt/norm
+
+go 1.22
+
+require (
+	github.com/gregoryv/xr v0.0.0-20261016183929-c762613f6805
+	golang.org/x/text v0.22.0
+)
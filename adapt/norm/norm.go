@@ -0,0 +1,29 @@
+// Package norm registers Unicode normalization forms NFC, NFD, NFKC
+// and NFKD for field tag normalize on xr.PickerDefault when imported
+//
+//	import _ "github.com/gregoryv/xr/adapt/norm"
+package norm
+
+import (
+	"github.com/gregoryv/xr"
+	"golang.org/x/text/unicode/norm"
+)
+
+func init() {
+	Register(xr.PickerDefault)
+}
+
+// Register normalization forms on the given picker. Panics if
+// already registered.
+func Register(p *xr.Picker) {
+	for name, form := range forms {
+		p.RegisterNormalizer(name, form.String)
+	}
+}
+
+var forms = map[string]norm.Form{
+	"NFC":  norm.NFC,
+	"NFD":  norm.NFD,
+	"NFKC": norm.NFKC,
+	"NFKD": norm.NFKD,
+}
@@ -0,0 +1,20 @@
+package norm
+
+import (
+	"fmt"
+	"net/http/httptest"
+
+	"github.com/gregoryv/xr"
+)
+
+func Example() {
+	var x struct {
+		Name string `query:"name" normalize:"NFC"`
+	}
+	// e followed by combining acute accent
+	r := httptest.NewRequest("GET", "/?name=cafe%CC%81", nil)
+	_ = xr.Pick(&x, r)
+	fmt.Println(x.Name == "café", len(x.Name))
+	// output:
+	// true 5
+}
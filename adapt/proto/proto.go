@@ -0,0 +1,80 @@
+// Package proto registers a decoder for application/x-protobuf
+// bodies on xr.PickerDefault when imported
+//
+//	import _ "github.com/gregoryv/xr/adapt/proto"
+//
+// The body is unmarshaled into the destination if it's a
+// proto.Message, or into a field tagged `body:"proto"`, e.g.
+//
+//	var x struct {
+//		Tenant string    `header:"x-tenant"`
+//		Order  *pb.Order `body:"proto"`
+//	}
+package proto
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/gregoryv/xr"
+	"google.golang.org/protobuf/proto"
+)
+
+func init() {
+	Register(xr.PickerDefault)
+}
+
+// Register decoder for application/x-protobuf on the given picker.
+func Register(p *xr.Picker) {
+	p.Register("application/x-protobuf", Decoder)
+}
+
+// Decoder returns a protobuf decoder of r.
+func Decoder(r io.Reader) xr.Decoder {
+	return decoder{r}
+}
+
+type decoder struct {
+	r io.Reader
+}
+
+func (d decoder) Decode(v any) error {
+	m, err := message(v)
+	if err != nil || m == nil {
+		return err
+	}
+	data, err := io.ReadAll(d.r)
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(data, m)
+}
+
+// message returns v if it's a message, or the message of the field
+// tagged `body:"proto"` allocating it if nil. Returns nil if there is
+// no such field.
+func message(v any) (proto.Message, error) {
+	if m, ok := v.(proto.Message); ok {
+		return m, nil
+	}
+	obj := reflect.ValueOf(v).Elem()
+	for i := 0; i < obj.NumField(); i++ {
+		if obj.Type().Field(i).Tag.Get("body") == "proto" {
+			return fieldMessage(obj.Field(i))
+		}
+	}
+	return nil, nil
+}
+
+func fieldMessage(field reflect.Value) (proto.Message, error) {
+	if !field.Type().Implements(messageType) {
+		return nil, fmt.Errorf("body proto %v: unsupported", field.Type())
+	}
+	if field.IsNil() {
+		field.Set(reflect.New(field.Type().Elem()))
+	}
+	return field.Interface().(proto.Message), nil
+}
+
+var messageType = reflect.TypeFor[proto.Message]()
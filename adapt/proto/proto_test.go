@@ -0,0 +1,47 @@
+package proto
+
+import (
+	"bytes"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gregoryv/xr"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func Example() {
+	var x struct {
+		Tenant string                  `header:"x-tenant"`
+		Name   *wrapperspb.StringValue `body:"proto"`
+	}
+	data, _ := proto.Marshal(wrapperspb.String("gopher"))
+	r := httptest.NewRequest("POST", "/names", bytes.NewReader(data))
+	r.Header.Set("content-type", "application/x-protobuf")
+	r.Header.Set("x-tenant", "acme")
+	_ = xr.Pick(&x, r)
+	fmt.Println(x.Tenant, x.Name.GetValue())
+	// output:
+	// acme gopher
+}
+
+func TestDecoder_message(t *testing.T) {
+	data, _ := proto.Marshal(wrapperspb.Int64(42))
+	var m wrapperspb.Int64Value
+	if err := Decoder(bytes.NewReader(data)).Decode(&m); err != nil {
+		t.Fatal(err)
+	}
+	if m.GetValue() != 42 {
+		t.Error(m.GetValue())
+	}
+}
+
+func TestDecoder_unsupported(t *testing.T) {
+	var x struct {
+		Name string `body:"proto"`
+	}
+	if err := Decoder(bytes.NewReader(nil)).Decode(&x); err == nil {
+		t.Error("expect error")
+	}
+}
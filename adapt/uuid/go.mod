@@ -0,0 +1,8 @@
+module github.com/gregoryv/xr/adapt/uuid
+
+go 1.22
+
+require (
+	github.com/google/uuid v1.6.0
+	github.com/gregoryv/xr v0.0.0-20261016183929-c762613f6805
+)
@@ -0,0 +1,20 @@
+// Package uuid registers a setter for [uuid.UUID] fields on
+// xr.PickerDefault when imported
+//
+//	import _ "github.com/gregoryv/xr/adapt/uuid"
+package uuid
+
+import (
+	"github.com/google/uuid"
+	"github.com/gregoryv/xr"
+)
+
+func init() {
+	Register(xr.PickerDefault)
+}
+
+// Register setter for uuid.UUID fields on the given picker. Panics
+// if already registered.
+func Register(p *xr.Picker) {
+	xr.UseSetterFor(p, uuid.Parse)
+}
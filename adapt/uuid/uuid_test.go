@@ -0,0 +1,27 @@
+package uuid
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/google/uuid"
+	"github.com/gregoryv/xr"
+)
+
+func Example() {
+	var x struct {
+		Id uuid.UUID `path:"id"`
+	}
+	u := "/items/f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	r := httptest.NewRequest("GET", u, http.NoBody)
+	r.SetPathValue("id", "f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	_ = xr.Pick(&x, r)
+	fmt.Println(x.Id)
+
+	r.SetPathValue("id", "jibberish")
+	fmt.Println(xr.Pick(&x, r))
+	// output:
+	// f47ac10b-58cc-4372-a567-0e02b2c3d479
+	// pick Id from path[id]: invalid UUID length: 9
+}
@@ -0,0 +1,74 @@
+package xr
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ErrMalformedAuth is the cause when the authorization header has
+// the scheme of a field tag, e.g. basicauth, but malformed
+// credentials, or another scheme than bearer for fields tagged
+// bearer.
+var ErrMalformedAuth = errors.New("malformed authorization")
+
+// checkAuth returns a pick error if the field of plan is read from
+// credentials of a malformed authorization header.
+func (p *Picker) checkAuth(plan fieldPlan, r *http.Request) error {
+	check, found := authChecks[plan.source]
+	if !found || check(r) {
+		return nil
+	}
+	name, _ := p.tagName(plan.StructField, plan.source)
+	src := source{kind: plan.source, name: name}
+	return NewPickError(plan.Name, src.String(), ErrMalformedAuth)
+}
+
+// authChecks return false for malformed credentials of the source
+// kind, ignoring other schemes.
+var authChecks = map[string]func(*http.Request) bool{
+	"basicauth": func(r *http.Request) bool {
+		_, _, ok := r.BasicAuth()
+		return ok || !hasScheme(r, "Basic")
+	},
+	"bearer": func(r *http.Request) bool {
+		v := r.Header.Get("Authorization")
+		return v == "" || bearerToken(v) != ""
+	},
+}
+
+// hasScheme returns true if the authorization header of r uses the
+// given scheme, compared case-insensitively.
+func hasScheme(r *http.Request, scheme string) bool {
+	v, _, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+	return strings.EqualFold(v, scheme)
+}
+
+// readBasicAuth returns the username or password of the basic
+// authorization header by name. Panics on unknown names.
+func readBasicAuth(r *http.Request, name string) []string {
+	username, password, _ := r.BasicAuth()
+	switch name {
+	case "username":
+		return nonEmpty(username)
+	case "password":
+		return nonEmpty(password)
+	}
+	panic(misuse("basicauth[%s]: unknown", name))
+}
+
+// readBearer returns the token of a bearer authorization header. The
+// name of the bearer tag is not used, e.g. `bearer:""`.
+func readBearer(r *http.Request, _ string) []string {
+	return nonEmpty(bearerToken(r.Header.Get("Authorization")))
+}
+
+// bearerToken returns the token of authorization header value v,
+// empty if it's not of the bearer scheme.
+func bearerToken(v string) string {
+	scheme, token, _ := strings.Cut(v, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
@@ -0,0 +1,92 @@
+package xr
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExamplePick_basicAuth() {
+	var x struct {
+		Username string `basicauth:"username" required:"true"`
+		Password string `basicauth:"password"`
+	}
+	r := httptest.NewRequest("GET", "/", http.NoBody)
+	r.SetBasicAuth("john", "secret")
+	_ = Pick(&x, r)
+	fmt.Println(x.Username, x.Password)
+
+	r.Header.Set("Authorization", "Basic jibberish")
+	fmt.Println(Pick(&x, r))
+	// output:
+	// john secret
+	// pick Username from basicauth[username]: malformed authorization
+}
+
+func TestPick_basicAuth(t *testing.T) {
+	cases := map[string]bool{
+		"":                       true,
+		"Bearer abc":             true,
+		"basic am9objpzZWNyZXQ=": true,
+		"Basic":                  false,
+		"Basic am9obg==":         false, // no colon
+	}
+	for header, ok := range cases {
+		var x struct {
+			Username string `basicauth:""`
+		}
+		r := httptest.NewRequest("GET", "/", http.NoBody)
+		r.Header.Set("Authorization", header)
+		if err := Pick(&x, r); (err == nil) != ok {
+			t.Errorf("%q: %v", header, err)
+		}
+	}
+}
+
+func Test_readBasicAuth_unknown(t *testing.T) {
+	defer catchPanic(t)
+	var x struct {
+		V string `basicauth:"jibberish"`
+	}
+	r := httptest.NewRequest("GET", "/", http.NoBody)
+	r.SetBasicAuth("john", "secret")
+	_ = Pick(&x, r)
+}
+
+func ExamplePick_bearer() {
+	var x struct {
+		Token string `bearer:"" required:"true"`
+	}
+	r := httptest.NewRequest("GET", "/", http.NoBody)
+	r.Header.Set("Authorization", "Bearer mF_9.B5f-4.1JqM")
+	_ = Pick(&x, r)
+	fmt.Println(x.Token)
+
+	r.Header.Set("Authorization", "Token mF_9.B5f-4.1JqM")
+	fmt.Println(Pick(&x, r))
+	// output:
+	// mF_9.B5f-4.1JqM
+	// pick Token from bearer[token]: malformed authorization
+}
+
+func TestPick_bearer(t *testing.T) {
+	cases := map[string]bool{
+		"":             true,
+		"Bearer abc":   true,
+		"bearer abc":   true,
+		"Bearer":       false,
+		"Bearer  ":     false,
+		"Basic am9obg": false,
+	}
+	for header, ok := range cases {
+		var x struct {
+			Token string `bearer:""`
+		}
+		r := httptest.NewRequest("GET", "/", http.NoBody)
+		r.Header.Set("Authorization", header)
+		if err := Pick(&x, r); (err == nil) != ok {
+			t.Errorf("%q: %v", header, err)
+		}
+	}
+}
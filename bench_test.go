@@ -21,3 +21,16 @@ func BenchmarkPick(b *testing.B) {
 		_ = Pick(&x, r)
 	}
 }
+
+func BenchmarkPick_values(b *testing.B) {
+	u := "/person/123?group=aliens&copies=10&flag=true"
+	r := httptest.NewRequest("GET", u, nil)
+	r.SetPathValue("id", "123")
+	r.Header.Set("authorization", "Bearer ...token...")
+
+	var x PersonCreate
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = Pick(&x, r)
+	}
+}
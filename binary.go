@@ -0,0 +1,70 @@
+package xr
+
+import (
+	"io"
+	"reflect"
+)
+
+// BinaryDecoder returns a decoder factory setting the field tagged
+// `body:"binary"`, declared or by a mapping of p, to the body. Fields
+// of type []byte get the whole body and io.Reader fields a reader of
+// it. Bodies larger than maxBytes result in ErrTooLarge, for readers
+// when read. Register it with
+//
+//	p.Register("application/octet-stream", p.BinaryDecoder(1<<20))
+func (p *Picker) BinaryDecoder(maxBytes int64) func(io.Reader) Decoder {
+	return func(r io.Reader) Decoder {
+		return decoderFunc(func(v any) error {
+			return p.decodeBinary(r, v, maxBytes)
+		})
+	}
+}
+
+// DefaultBinaryBytes is the max size of binary bodies decoded by
+// PickerDefault.
+const DefaultBinaryBytes = 32 << 20
+
+func (p *Picker) decodeBinary(r io.Reader, v any, maxBytes int64) error {
+	field := p.bodyField(v, "binary")
+	switch {
+	case !field.IsValid():
+		return nil
+
+	case field.Type() == readerType:
+		field.Set(reflect.ValueOf(&maxReader{r: r, left: maxBytes}))
+		return nil
+
+	case field.Type() == bytesType:
+		data, err := readAll(r, maxBytes)
+		field.SetBytes(data)
+		return err
+
+	default:
+		return unsupportedBody("binary", field)
+	}
+}
+
+var (
+	readerType = reflect.TypeFor[io.Reader]()
+	bytesType  = reflect.TypeFor[[]byte]()
+)
+
+// maxReader fails with ErrTooLarge if more than left bytes are
+// available.
+type maxReader struct {
+	r    io.Reader
+	left int64
+}
+
+func (m *maxReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > m.left+1 {
+		p = p[:m.left+1]
+	}
+	n, err := m.r.Read(p)
+	if int64(n) > m.left {
+		m.left = 0
+		return n - 1, ErrTooLarge
+	}
+	m.left -= int64(n)
+	return n, err
+}
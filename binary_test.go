@@ -0,0 +1,119 @@
+package xr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func ExamplePicker_BinaryDecoder() {
+	var x struct {
+		Name string `query:"name"`
+		Data []byte `body:"binary"`
+	}
+	body := strings.NewReader("\x00\x01\x02")
+	r := httptest.NewRequest("PUT", "/?name=blob", body)
+	r.Header.Set("content-type", "application/octet-stream")
+	_ = Pick(&x, r)
+	fmt.Printf("%s %v", x.Name, x.Data)
+	// output:
+	// blob [0 1 2]
+}
+
+func ExamplePicker_BinaryDecoder_reader() {
+	var x struct {
+		Data io.Reader `body:"binary"`
+	}
+	body := strings.NewReader("streamed")
+	r := httptest.NewRequest("PUT", "/", body)
+	r.Header.Set("content-type", "application/octet-stream")
+	_ = Pick(&x, r)
+	data, _ := io.ReadAll(x.Data)
+	fmt.Println(string(data))
+	// output:
+	// streamed
+}
+
+func TestPicker_BinaryDecoder_tooLarge(t *testing.T) {
+	p := NewPicker()
+	p.Register("application/octet-stream", p.BinaryDecoder(3))
+	var x struct {
+		Data []byte `body:"binary"`
+	}
+	err := p.Pick(&x, binaryRequest("hello"))
+	if !errors.Is(err, ErrTooLarge) {
+		t.Error("expect ErrTooLarge, got", err)
+	}
+}
+
+func TestPicker_BinaryDecoder_readerTooLarge(t *testing.T) {
+	p := NewPicker()
+	p.Register("application/octet-stream", p.BinaryDecoder(3))
+	var x struct {
+		Data io.Reader `body:"binary"`
+	}
+	if err := p.Pick(&x, binaryRequest("hello")); err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(x.Data)
+	if !errors.Is(err, ErrTooLarge) || string(data) != "hel" {
+		t.Errorf("got %q, %v", data, err)
+	}
+}
+
+func TestPicker_BinaryDecoder_unsupported(t *testing.T) {
+	var x struct {
+		Data string `body:"binary"`
+	}
+	if err := Pick(&x, binaryRequest("hello")); err == nil {
+		t.Error("expect error")
+	}
+}
+
+func binaryRequest(body string) *http.Request {
+	r := httptest.NewRequest("PUT", "/", strings.NewReader(body))
+	r.Header.Set("content-type", "application/octet-stream")
+	return r
+}
+
+func TestPicker_BinaryDecoder_readerAfterPick(t *testing.T) {
+	var x struct {
+		Data io.Reader `body:"binary"`
+	}
+	_ = Pick(&x, binaryRequest("streamed"))
+	done := make(chan struct{})
+	go func() {
+		// reuses the pooled buffer of the first pick
+		var y struct {
+			Name string `json:"name"`
+		}
+		r := httptest.NewRequest("POST", "/", strings.NewReader("{}"))
+		r.Header.Set("content-type", "application/json")
+		_ = Pick(&y, r)
+		close(done)
+	}()
+	data, _ := io.ReadAll(x.Data)
+	<-done
+	if string(data) != "streamed" {
+		t.Error(string(data))
+	}
+}
+
+func TestPicker_BinaryDecoder_mapping(t *testing.T) {
+	type Blob struct {
+		Data []byte
+	}
+	p := NewPicker()
+	p.Register("application/octet-stream", p.BinaryDecoder(10))
+	p.UseMapping(Blob{}, NewMapping().Field("Data", `body:"binary"`))
+	var x Blob
+	r := httptest.NewRequest("PUT", "/", strings.NewReader("blob"))
+	r.Header.Set("content-type", "application/octet-stream")
+	if err := p.Pick(&x, r); err != nil || string(x.Data) != "blob" {
+		t.Error(x, err)
+	}
+}
@@ -0,0 +1,106 @@
+package xr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// BodyError describes where in the body decoding failed.
+type BodyError struct {
+	// Offset in bytes where the error was detected
+	Offset int64
+
+	// Field path of a value with the wrong type, e.g. address.zip
+	Field string
+
+	// Excerpt of the body around Offset
+	Excerpt string
+
+	// decoding error
+	Cause error
+}
+
+func (e *BodyError) Error() string {
+	return fmt.Sprintf("%s at offset %d near %q",
+		e.Cause, e.Offset, e.Excerpt,
+	)
+}
+
+// Unwrap returns the cause.
+func (e *BodyError) Unwrap() error {
+	return e.Cause
+}
+
+// newBodyError returns a *BodyError if the location of err within
+// body is known, otherwise err as is.
+func newBodyError(err error, body []byte) error {
+	e := BodyError{Cause: err}
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		e.Offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		e.Offset = typeErr.Offset
+		e.Field = typeErr.Field
+	default:
+		return err
+	}
+	e.Excerpt = excerpt(body, e.Offset, excerptWidth)
+	return &e
+}
+
+// excerpt returns at most width bytes before and after offset in
+// body.
+func excerpt(body []byte, offset int64, width int64) string {
+	from := max(0, offset-width)
+	to := min(int64(len(body)), offset+width)
+	if from > to {
+		return ""
+	}
+	return strings.ToValidUTF8(string(body[from:to]), "")
+}
+
+const excerptWidth = 16
+
+// getBuffer returns an empty buffer from a pool shared by all
+// pickers, see putBuffer.
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+// putBuffer returns buf to the pool unless it has grown too large
+// to keep.
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() <= maxPooledBuffer {
+		bufferPool.Put(buf)
+	}
+}
+
+var bufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+const maxPooledBuffer = 64 << 10
+
+// detachableWriter writes to w until detached, after which writes
+// are discarded.
+type detachableWriter struct {
+	w io.Writer
+}
+
+func (d *detachableWriter) Write(p []byte) (int, error) {
+	if d.w == nil {
+		return len(p), nil
+	}
+	return d.w.Write(p)
+}
+
+func (d *detachableWriter) detach() { d.w = nil }
@@ -0,0 +1,53 @@
+package xr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func ExampleBodyError() {
+	data := `{"name":"John", "width": "wide"}`
+	r := httptest.NewRequest("POST", "/", strings.NewReader(data))
+	r.Header.Set("content-type", "application/json")
+
+	var x PersonCreate
+	err := Pick(&x, r)
+	var e *BodyError
+	if errors.As(err, &e) {
+		fmt.Println(e.Field)
+		fmt.Println(e.Offset)
+		fmt.Println(e.Excerpt)
+	}
+	// output:
+	// width
+	// 31
+	//  "width": "wide"}
+}
+
+func Test_newBodyError_unknown(t *testing.T) {
+	if err := newBodyError(io.EOF, nil); err != io.EOF {
+		t.Error("expect err as is, got", err)
+	}
+}
+
+func Test_excerpt(t *testing.T) {
+	body := []byte("0123456789")
+	cases := []struct {
+		offset, width int64
+		exp           string
+	}{
+		{5, 2, "3456"},
+		{0, 2, "01"},
+		{10, 3, "789"},
+		{20, 3, ""},
+	}
+	for _, c := range cases {
+		if got := excerpt(body, c.offset, c.width); got != c.exp {
+			t.Errorf("%v: got %q", c, got)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package xr
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+func checkMinimum(field reflect.Value, arg string) error {
+	return checkBound("minimum", field, arg, func(v, limit float64) bool {
+		return v >= limit
+	})
+}
+
+func checkMaximum(field reflect.Value, arg string) error {
+	return checkBound("maximum", field, arg, func(v, limit float64) bool {
+		return v <= limit
+	})
+}
+
+func checkExclusiveMinimum(field reflect.Value, arg string) error {
+	return checkBound("exclusiveMinimum", field, arg,
+		func(v, limit float64) bool { return v > limit },
+	)
+}
+
+func checkExclusiveMaximum(field reflect.Value, arg string) error {
+	return checkBound("exclusiveMaximum", field, arg,
+		func(v, limit float64) bool { return v < limit },
+	)
+}
+
+// checkMultipleOf returns an error if number field is not a multiple
+// of arg, which must be greater than 0.
+func checkMultipleOf(field reflect.Value, arg string) error {
+	if err := checkMultipleOfArg(nil, arg); err != nil {
+		return fmt.Errorf("multipleOf: %w", err)
+	}
+	return checkBound("multipleOf", field, arg, isMultiple)
+}
+
+// isMultiple returns true if v is a multiple of d, allowing for
+// rounding errors of floats.
+func isMultiple(v, d float64) bool {
+	q := v / d
+	return d > 0 && math.Abs(q-math.Round(q)) < 1e-9
+}
+
+// checkBound returns an error if number field is not within the
+// limit of arg.
+func checkBound(tag string, field reflect.Value, arg string,
+	within func(v, limit float64) bool,
+) error {
+	if !isReal(field.Kind()) {
+		return fmt.Errorf("%s %v: unsupported", tag, field.Kind())
+	}
+	limit, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return fmt.Errorf("%s: %w", tag, err)
+	}
+	if !within(toFloat(field), limit) {
+		return fmt.Errorf("%s %s: got %v", tag, arg, field)
+	}
+	return nil
+}
+
+// checkArg returns the argument of the check tag of field. The
+// draft-04 form of exclusive bounds, e.g.
+// `minimum:"0" exclusiveMinimum:"true"`, is checked as
+// `exclusiveMinimum:"0"`.
+func checkArg(tag reflect.StructTag, name string) (string, bool) {
+	if flag, found := exclusiveOf[name]; found && tag.Get(flag) == "true" {
+		// checked as exclusive
+		return "", false
+	}
+	if limit, found := limitOf[name]; found && isFlag(tag.Get(name)) {
+		return flagLimit(tag, name, limit)
+	}
+	return tag.Lookup(name)
+}
+
+// flagLimit returns the argument of the limit tag and true if the
+// draft-04 flag name is set and the limit tag found.
+func flagLimit(tag reflect.StructTag, name, limit string) (string, bool) {
+	arg, found := tag.Lookup(limit)
+	return arg, found && tag.Get(name) == "true"
+}
+
+// checkFlagLimit returns an error if field has the draft-04 form of
+// an exclusive bound without the limit, e.g. `exclusiveMinimum:"true"`
+// without a minimum tag.
+func checkFlagLimit(field reflect.StructField) error {
+	for flag, limit := range limitOf {
+		_, found := field.Tag.Lookup(limit)
+		if isFlag(field.Tag.Get(flag)) && !found {
+			return fmt.Errorf("%s: %s: no %s", field.Name, flag, limit)
+		}
+	}
+	return nil
+}
+
+var (
+	exclusiveOf = map[string]string{
+		"minimum": "exclusiveMinimum",
+		"maximum": "exclusiveMaximum",
+	}
+	limitOf = map[string]string{
+		"exclusiveMinimum": "minimum",
+		"exclusiveMaximum": "maximum",
+	}
+)
+
+// isFlag returns true if v is the draft-04 form of an exclusive
+// bound.
+func isFlag(v string) bool {
+	return v == "true" || v == "false"
+}
@@ -0,0 +1,121 @@
+package xr
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func ExamplePick_exclusiveMinimum() {
+	var x struct {
+		Amount float64 `query:"amount" exclusiveMinimum:"0"`
+	}
+	r := httptest.NewRequest("GET", "/?amount=0", nil)
+	fmt.Println(Pick(&x, r))
+	// output:
+	// pick Amount from query[amount]: exclusiveMinimum 0: got 0
+}
+
+func TestPick_bounds(t *testing.T) {
+	cases := []struct {
+		tag   string
+		value string
+		ok    bool
+	}{
+		{`minimum:"1"`, "1", true},
+		{`minimum:"1"`, "0", false},
+		{`maximum:"9"`, "9", true},
+		{`maximum:"9"`, "10", false},
+		{`exclusiveMinimum:"1"`, "1", false},
+		{`exclusiveMaximum:"9"`, "9", false},
+		{`exclusiveMaximum:"9"`, "8", true},
+		{`minimum:"1" exclusiveMinimum:"true"`, "1", false},
+		{`minimum:"1" exclusiveMinimum:"true"`, "2", true},
+		{`minimum:"1" exclusiveMinimum:"false"`, "1", true},
+		{`maximum:"9" exclusiveMaximum:"true"`, "9", false},
+		{`exclusiveMinimum:"true"`, "0", true},
+		{`minimum:"x"`, "1", false},
+	}
+	for _, c := range cases {
+		err := pickTaggedInt(c.tag, c.value)
+		if (err == nil) != c.ok {
+			t.Error(c.tag, c.value, err)
+		}
+	}
+}
+
+func TestCheck_flagLimit(t *testing.T) {
+	var x struct {
+		N int `query:"n" exclusiveMaximum:"true"`
+	}
+	err := Check(&x)
+	if exp := "N: exclusiveMaximum: no maximum"; fmt.Sprint(err) != exp {
+		t.Errorf("got %v, exp %s", err, exp)
+	}
+}
+
+func TestPick_boundsUnsupported(t *testing.T) {
+	var x struct {
+		Name string `query:"name" minimum:"1"`
+	}
+	r := httptest.NewRequest("GET", "/?name=a", nil)
+	if err := Pick(&x, r); err == nil {
+		t.Error("expect error")
+	}
+}
+
+// pickTaggedInt picks value of query parameter n into an int field
+// with the given validation tag.
+func pickTaggedInt(tag, value string) error {
+	t := reflect.StructOf([]reflect.StructField{{
+		Name: "N",
+		Type: reflect.TypeFor[int](),
+		Tag:  reflect.StructTag(`query:"n" ` + tag),
+	}})
+	r := httptest.NewRequest("GET", "/?n="+value, nil)
+	return Pick(reflect.New(t).Interface(), r)
+}
+
+func ExamplePick_multipleOf() {
+	var x struct {
+		Size int `query:"size" multipleOf:"5"`
+	}
+	r := httptest.NewRequest("GET", "/?size=12", nil)
+	fmt.Println(Pick(&x, r))
+	// output:
+	// pick Size from query[size]: multipleOf 5: got 12
+}
+
+func Test_isMultiple(t *testing.T) {
+	cases := []struct {
+		v, d float64
+		ok   bool
+	}{
+		{10, 5, true},
+		{-10, 5, true},
+		{0, 5, true},
+		{11, 5, false},
+		{0.3, 0.1, true},
+		{0.35, 0.1, false},
+		{10, 0, false},
+		{10, -5, false},
+	}
+	for _, c := range cases {
+		if got := isMultiple(c.v, c.d); got != c.ok {
+			t.Error(c.v, c.d, got)
+		}
+	}
+}
+
+func TestPick_multipleOfZero(t *testing.T) {
+	var x struct {
+		Size int `query:"size" multipleOf:"0"`
+	}
+	r := httptest.NewRequest("GET", "/?size=12", nil)
+	err := Pick(&x, r)
+	if err == nil || !strings.Contains(err.Error(), "not greater than 0") {
+		t.Error(err)
+	}
+}
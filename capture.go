@@ -0,0 +1,118 @@
+package xr
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// captureTag returns the source and pattern of a field tag ending
+// with *, e.g. `header:"*"`, `header:"X-Meta-*"`, `query:"*"` or `cookie:"*"`.
+func captureTag(tag reflect.StructTag) (string, string, bool) {
+	for source := range capturers {
+		if v := tag.Get(source); strings.HasSuffix(v, "*") {
+			return source, v, true
+		}
+	}
+	return "", "", false
+}
+
+// capture sets field i of obj to all values of the given source
+// matching pattern.
+func (p *Picker) capture(
+	obj reflect.Value, i int, r *http.Request, source, pattern string,
+) error {
+	field := p.field(obj, i)
+	if !field.IsExported() {
+		panic(misuse("%v: private", field.Name))
+	}
+	if err := capturers[source](obj.Elem().Field(i), r, pattern); err != nil {
+		src := fmt.Sprintf("%s[%s]", source, pattern)
+		return NewPickError(field.Name, src, err)
+	}
+	return nil
+}
+
+// capturers map sources to funcs setting a field from many values
+var capturers = map[string]capturer{
+	"header": captureHeader,
+	"query":  captureQuery,
+	"cookie": captureCookies,
+}
+
+type capturer func(field reflect.Value, r *http.Request, pattern string) error
+
+// captureHeader sets a http.Header field to a copy of all request
+// headers with the prefix of pattern.
+func captureHeader(
+	field reflect.Value, r *http.Request, pattern string,
+) error {
+	if field.Type() != headerType {
+		return fmt.Errorf("capture %v: unsupported", field.Type())
+	}
+	prefix := http.CanonicalHeaderKey(strings.TrimSuffix(pattern, "*"))
+	h := make(http.Header)
+	for k, v := range r.Header {
+		if strings.HasPrefix(k, prefix) {
+			h[k] = append([]string(nil), v...)
+		}
+	}
+	field.Set(reflect.ValueOf(h))
+	return nil
+}
+
+// captureQuery sets a url.Values field to the query parameters
+// with the prefix of pattern. A string field is set to the raw query
+// if pattern is "*".
+func captureQuery(
+	field reflect.Value, r *http.Request, pattern string,
+) error {
+	switch {
+	case field.Type() == valuesType:
+		prefix := strings.TrimSuffix(pattern, "*")
+		field.Set(reflect.ValueOf(filterValues(r.URL.Query(), prefix)))
+		return nil
+
+	case field.Kind() == reflect.String && pattern == "*":
+		field.SetString(r.URL.RawQuery)
+		return nil
+	}
+	return fmt.Errorf("capture %v: unsupported", field.Type())
+}
+
+// filterValues returns values with keys starting with prefix.
+func filterValues(values url.Values, prefix string) url.Values {
+	for k := range values {
+		if !strings.HasPrefix(k, prefix) {
+			delete(values, k)
+		}
+	}
+	return values
+}
+
+// captureCookies sets a []*http.Cookie field to the request cookies
+// with names starting with the prefix of pattern.
+func captureCookies(
+	field reflect.Value, r *http.Request, pattern string,
+) error {
+	if field.Type() != cookiesType {
+		return fmt.Errorf("capture %v: unsupported", field.Type())
+	}
+	prefix := strings.TrimSuffix(pattern, "*")
+	var cookies []*http.Cookie
+	for _, c := range r.Cookies() {
+		if strings.HasPrefix(c.Name, prefix) {
+			cookies = append(cookies, c)
+		}
+	}
+	field.Set(reflect.ValueOf(cookies))
+	return nil
+}
+
+var (
+	cookiesType = reflect.TypeOf([]*http.Cookie{})
+	headerType  = reflect.TypeOf(http.Header{})
+	valuesType  = reflect.TypeOf(url.Values{})
+)
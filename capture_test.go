@@ -0,0 +1,122 @@
+package xr
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func ExamplePick_allHeaders() {
+	var x struct {
+		Meta http.Header `header:"X-Meta-*"`
+		All  http.Header `header:"*"`
+	}
+	r := httptest.NewRequest("GET", "/", http.NoBody)
+	r.Header.Set("x-meta-owner", "john")
+	r.Header.Set("x-meta-size", "12")
+	r.Header.Set("accept", "text/plain")
+	_ = Pick(&x, r)
+	fmt.Println(len(x.Meta), len(x.All))
+	fmt.Println(x.Meta.Get("x-meta-owner"))
+	// output:
+	// 2 3
+	// john
+}
+
+func Test_captureHeader_copy(t *testing.T) {
+	var x struct {
+		All http.Header `header:"*"`
+	}
+	r := httptest.NewRequest("GET", "/", http.NoBody)
+	r.Header.Set("color", "red")
+	_ = Pick(&x, r)
+	x.All.Set("color", "blue")
+	if got := r.Header.Get("color"); got != "red" {
+		t.Error("request header modified", got)
+	}
+}
+
+func Test_captureHeader_unsupported(t *testing.T) {
+	var x struct {
+		All map[string]string `header:"*"`
+	}
+	r := httptest.NewRequest("GET", "/", http.NoBody)
+	if err := Pick(&x, r); err == nil {
+		t.Error("expect error")
+	}
+}
+
+func Test_capture_private(t *testing.T) {
+	defer catchPanic(t)
+	var x struct {
+		all http.Header `header:"*"`
+	}
+	r := httptest.NewRequest("GET", "/", http.NoBody)
+	_ = Pick(&x, r)
+}
+
+func ExamplePick_rawQuery() {
+	var x struct {
+		Raw    string     `query:"*"`
+		Params url.Values `query:"*"`
+		Sort   url.Values `query:"sort*"`
+	}
+	u := "/?sort=name&sortOrder=asc&q=hello+world"
+	r := httptest.NewRequest("GET", u, http.NoBody)
+	_ = Pick(&x, r)
+	fmt.Println(x.Raw)
+	fmt.Println(x.Params.Get("q"))
+	fmt.Println(x.Sort.Encode())
+	// output:
+	// sort=name&sortOrder=asc&q=hello+world
+	// hello world
+	// sort=name&sortOrder=asc
+}
+
+func Test_captureQuery_unsupported(t *testing.T) {
+	r := httptest.NewRequest("GET", "/?a=1", http.NoBody)
+	{
+		var x struct {
+			Q int `query:"*"`
+		}
+		if err := Pick(&x, r); err == nil {
+			t.Error("expect error")
+		}
+	}
+	{ // prefix into string
+		var x struct {
+			Q string `query:"a*"`
+		}
+		if err := Pick(&x, r); err == nil {
+			t.Error("expect error")
+		}
+	}
+}
+
+func ExamplePick_allCookies() {
+	var x struct {
+		Cookies []*http.Cookie `cookie:"*"`
+		Consent []*http.Cookie `cookie:"consent_*"`
+	}
+	r := httptest.NewRequest("GET", "/", http.NoBody)
+	r.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+	r.AddCookie(&http.Cookie{Name: "consent_ads", Value: "no"})
+	_ = Pick(&x, r)
+	fmt.Println(len(x.Cookies))
+	fmt.Println(x.Consent[0])
+	// output:
+	// 2
+	// consent_ads=no
+}
+
+func Test_captureCookies_unsupported(t *testing.T) {
+	var x struct {
+		C []http.Cookie `cookie:"*"`
+	}
+	r := httptest.NewRequest("GET", "/", http.NoBody)
+	if err := Pick(&x, r); err == nil {
+		t.Error("expect error")
+	}
+}
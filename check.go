@@ -0,0 +1,261 @@
+package xr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Check returns problems with the field tags of v, a struct or
+// pointer to struct, that otherwise surface when picking, e.g.
+// private fields with source tags, types without a setter,
+// malformed arguments of validation tags, e.g. `minimum:"x"`,
+// unknown names of ctx, basicauth or generate tags, or trailers
+// combined with fields streaming the body. Use it in tests or when
+// starting to fail fast. Problems of all fields, including nested
+// ones, are joined.
+func (p *Picker) Check(v any) error {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return fmt.Errorf("Check %T: not a struct", v)
+	}
+	return errors.Join(append(p.checkStruct(t), p.checkTrailer(t))...)
+}
+
+func (p *Picker) checkStruct(t reflect.Type) []error {
+	obj := reflect.New(t)
+	var errs []error
+	for i := 0; i < t.NumField(); i++ {
+		errs = append(errs, p.checkStructField(p.field(obj, i))...)
+	}
+	return errs
+}
+
+func (p *Picker) checkStructField(field reflect.StructField) []error {
+	if p.isNested(field) {
+		var errs []error
+		for _, err := range p.checkStruct(nestedType(field)) {
+			errs = append(errs, fmt.Errorf("%s.%w", field.Name, err))
+		}
+		return errs
+	}
+	errs := p.checkTagArgs(field)
+	if err := p.checkSource(field); err != nil {
+		errs = append([]error{err}, errs...)
+	}
+	return errs
+}
+
+// checkTrailer returns an error if struct t has fields tagged
+// trailer and fields streaming the body, which is drained before the
+// trailers are read.
+func (p *Picker) checkTrailer(t reflect.Type) error {
+	obj := reflect.New(t)
+	trailers := p.SourceFields(obj.Interface(), "trailer")
+	if len(trailers) == 0 {
+		return nil
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if field := p.field(obj, i); isStreaming(field) {
+			return fmt.Errorf("%s: streaming body with trailer %s",
+				field.Name, trailers[0].Name,
+			)
+		}
+	}
+	return nil
+}
+
+// checkSource returns an error if field has a source tag but can't
+// be set from its values.
+func (p *Picker) checkSource(field reflect.StructField) error {
+	if !hasReaderTag(field) {
+		return nil
+	}
+	if !field.IsExported() {
+		return fmt.Errorf("%s: private", field.Name)
+	}
+	if err := p.checkBasicAuth(field); err != nil {
+		return err
+	}
+	return p.checkValues(field)
+}
+
+// checkBasicAuth returns an error if field is tagged basicauth with
+// another name than username or password.
+func (p *Picker) checkBasicAuth(field reflect.StructField) error {
+	name, found := p.tagName(field, "basicauth")
+	if found && name != "username" && name != "password" {
+		return fmt.Errorf("%s: basicauth[%s]: unknown", field.Name, name)
+	}
+	return nil
+}
+
+func hasReaderTag(field reflect.StructField) bool {
+	for _, kind := range readerKinds {
+		if _, found := field.Tag.Lookup(kind); found {
+			return true
+		}
+	}
+	return false
+}
+
+// checkValues returns an error if field can't be set from the values
+// of its source. Fields of uploaded files or deepObject parameters
+// are not checked.
+func (p *Picker) checkValues(field reflect.StructField) error {
+	if source, pattern, found := captureTag(field.Tag); found {
+		return checkCapture(field, source, pattern)
+	}
+	if p.isFileField(field) || p.isDeepObject(field) {
+		return nil
+	}
+	return p.checkSettable(field)
+}
+
+// checkCapture returns an error if field capturing all values of
+// source matching pattern has an unsupported type, e.g. a
+// url.Values or string field tagged `query:"*"`. The field is
+// captured from an empty request.
+func checkCapture(field reflect.StructField, source, pattern string) error {
+	v := reflect.New(field.Type).Elem()
+	r := &http.Request{URL: &url.URL{}, Header: make(http.Header)}
+	if err := capturers[source](v, r, pattern); err != nil {
+		return fmt.Errorf("%s: %w", field.Name, err)
+	}
+	return nil
+}
+
+// checkSettable returns an error if there is no setter for field,
+// or its elements and pointed to values.
+func (p *Picker) checkSettable(field reflect.StructField) error {
+	elem := field
+	if p.isSlice(elem) {
+		elem.Type = elem.Type.Elem()
+	}
+	if p.isPointer(elem.Type) {
+		elem.Type = elem.Type.Elem()
+	}
+	if _, err := p.fieldSetter(elem); err != nil {
+		return fmt.Errorf("%s: %w", field.Name, err)
+	}
+	return nil
+}
+
+// checkTagArgs returns errors of malformed arguments of validation
+// tags of field.
+func (p *Picker) checkTagArgs(field reflect.StructField) []error {
+	var errs []error
+	for _, c := range tagArgChecks {
+		arg, found := field.Tag.Lookup(c.tag)
+		if !found {
+			continue
+		}
+		if err := c.fn(p, arg); err != nil {
+			err = fmt.Errorf("%s: %s: %w", field.Name, c.tag, err)
+			errs = append(errs, err)
+		}
+	}
+	if err := checkFlagLimit(field); err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// tagArgChecks validate arguments of field tags.
+var tagArgChecks = []struct {
+	tag string
+	fn  func(p *Picker, arg string) error
+}{
+	{"minimum", checkNumberArg},
+	{"maximum", checkNumberArg},
+	{"exclusiveMinimum", checkBoundArg},
+	{"exclusiveMaximum", checkBoundArg},
+	{"multipleOf", checkMultipleOfArg},
+	{"minLength", checkLengthArg},
+	{"maxLength", checkLengthArg},
+	{"pattern", func(p *Picker, arg string) error {
+		_, err := p.compile(arg)
+		return err
+	}},
+	{"transform", func(_ *Picker, arg string) error {
+		_, err := applyTransforms(arg, "")
+		return err
+	}},
+	{"encoding", func(_ *Picker, arg string) error {
+		if _, found := encodings[arg]; !found {
+			return fmt.Errorf("%s: unknown", arg)
+		}
+		return nil
+	}},
+	{"validate", (*Picker).checkRuleNames},
+	{"style", func(_ *Picker, arg string) error {
+		if _, found := styleSeparators[arg]; !found {
+			return fmt.Errorf("%s: unknown", arg)
+		}
+		return nil
+	}},
+	{"format", func(p *Picker, arg string) error {
+		if _, found := p.format(arg); !found {
+			return fmt.Errorf("%s: unknown", arg)
+		}
+		return nil
+	}},
+	{"ctx", func(p *Picker, arg string) error {
+		if _, found := lookup(p, p.contextKeys, arg); !found {
+			return fmt.Errorf("%s: unknown", arg)
+		}
+		return nil
+	}},
+	{"generate", func(p *Picker, arg string) error {
+		if _, found := lookup(p, p.generators, arg); !found {
+			return fmt.Errorf("%s: unknown", arg)
+		}
+		return nil
+	}},
+}
+
+func checkNumberArg(_ *Picker, arg string) error {
+	_, err := strconv.ParseFloat(arg, 64)
+	return err
+}
+
+// checkMultipleOfArg requires a number greater than 0, as defined by
+// JSON Schema.
+func checkMultipleOfArg(_ *Picker, arg string) error {
+	d, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return err
+	}
+	if d <= 0 {
+		return fmt.Errorf("%s: not greater than 0", arg)
+	}
+	return nil
+}
+
+// checkBoundArg allows the draft-04 form of exclusive bounds, e.g.
+// `exclusiveMinimum:"true"`.
+func checkBoundArg(p *Picker, arg string) error {
+	if isFlag(arg) {
+		return nil
+	}
+	return checkNumberArg(p, arg)
+}
+
+// checkLengthArg checks the limit and optional unit, e.g. "20,runes".
+func checkLengthArg(_ *Picker, arg string) error {
+	num, unit, _ := strings.Cut(arg, ",")
+	if _, err := strconv.Atoi(num); err != nil {
+		return err
+	}
+	if unit != "" && unit != "runes" && unit != "bytes" {
+		return fmt.Errorf("unit %s: unknown", unit)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package xr
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func ExampleCheck() {
+	type Address struct {
+		Zip string `query:"zip" maxLength:"5,chars"`
+	}
+	var x struct {
+		ID      int           `path:"id" minimum:"one"`
+		Done    chan struct{} `query:"done"`
+		secret  string        `header:"secret"`
+		Address `query:"address."`
+	}
+	_ = x.secret
+	fmt.Println(Check(&x))
+	// output:
+	// ID: minimum: strconv.ParseFloat: parsing "one": invalid syntax
+	// Done: set chan: unsupported
+	// secret: private
+	// Address.Zip: maxLength: unit chars: unknown
+}
+
+func TestCheck(t *testing.T) {
+	var x struct {
+		ID      *int        `path:"id" minimum:"1" exclusiveMinimum:"true"`
+		Tags    []string    `query:"tags" maxLength:"20,runes"`
+		Email   string      `header:"email" format:"email"`
+		Code    string      `query:"code" pattern:"^[a-z]+$"`
+		Color   Color       `query:"color"`
+		Headers http.Header `header:"*"`
+		Body    string      `json:"body" minLength:"1"`
+	}
+	if err := Check(x); err != nil {
+		t.Error(err)
+	}
+	if err := Check(1); err == nil {
+		t.Error("expected error for non struct")
+	}
+}
+
+func TestCheck_capture(t *testing.T) {
+	var x struct {
+		Headers map[string]string `header:"*"`
+		Params  url.Values        `query:"*"`
+		Raw     string            `query:"*"`
+		Filter  string            `query:"filter*"`
+	}
+	err := Check(&x)
+	exp := "Headers: capture map[string]string: unsupported\n" +
+		"Filter: capture string: unsupported"
+	if err == nil || err.Error() != exp {
+		t.Error(err)
+	}
+}
+
+func TestCheck_tagArgs(t *testing.T) {
+	var x struct {
+		A int    `exclusiveMaximum:"x"`
+		B int    `multipleOf:"x"`
+		C string `pattern:"["`
+		D string `format:"jibberish"`
+		E string `minLength:"x"`
+	}
+	if err := Check(&x); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestCheck_names(t *testing.T) {
+	var x struct {
+		User    string `ctx:"user"`
+		Account string `basicauth:""`
+		Secret  string `basicauth:"secret"`
+		Id      string `header:"x-id" generate:"ulid"`
+		Pass    string `basicauth:"password"`
+		Trace   string `header:"x-trace" generate:"uuid"`
+	}
+	exp := strings.Join([]string{
+		"User: ctx: user: unknown",
+		"Account: basicauth[account]: unknown",
+		"Secret: basicauth[secret]: unknown",
+		"Id: generate: ulid: unknown",
+	}, "\n")
+	if err := Check(&x); err == nil || err.Error() != exp {
+		t.Errorf("got\n%v\nexp\n%s", err, exp)
+	}
+	p := NewPicker()
+	p.RegisterContextKey("user", "user")
+	var y struct {
+		User string `ctx:"user"`
+	}
+	if err := p.Check(&y); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCheck_multipleOf(t *testing.T) {
+	var x struct {
+		A int     `multipleOf:"0"`
+		B float64 `multipleOf:"-0.5"`
+	}
+	exp := "A: multipleOf: 0: not greater than 0\n" +
+		"B: multipleOf: -0.5: not greater than 0"
+	if err := Check(&x); err == nil || err.Error() != exp {
+		t.Error(err)
+	}
+}
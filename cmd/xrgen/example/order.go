@@ -0,0 +1,31 @@
+// Package example shows code generated by xrgen, see order_xr.go.
+package example
+
+//go:generate go run github.com/gregoryv/xr/cmd/xrgen
+
+//xr:pick
+type Order struct {
+	ID      int     `path:"id" required:"true"`
+	Limit   uint8   `query:"limit"`
+	Price   float64 `query:"price"`
+	Express bool    `query:"express"`
+	Trace   string  `header:"x-trace"`
+	Session string  `cookie:"session"`
+	Note    string  `form:"note"`
+	Ignored string
+}
+
+// Item is not annotated.
+type Item struct {
+	Name string `query:"name"`
+}
+
+// Search names query parameters by the field name if the tag has no
+// name.
+//
+//xr:pick
+type Search struct {
+	Text  string `query:"" required:"true"`
+	Page  uint   `query:""`
+	Trace string `header:"x-trace" required:"true"`
+}
@@ -0,0 +1,101 @@
+package example
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gregoryv/xr"
+)
+
+func ExamplePickOrder() {
+	r := httptest.NewRequest("GET", "/orders/1?limit=5", nil)
+	r.SetPathValue("id", "1")
+	var x Order
+	err := PickOrder(&x, r)
+	fmt.Println(x.ID, x.Limit, err)
+	// output:
+	// 1 5 <nil>
+}
+
+func TestPickOrder(t *testing.T) {
+	cases := []string{
+		"/?limit=5&price=1.5&express=true",
+		"/?limit=256",
+		"/?price=x",
+		"/?express=x",
+	}
+	for _, target := range cases {
+		for _, id := range []string{"", "7", "x"} {
+			check(t, target, id)
+		}
+	}
+}
+
+func check(t *testing.T, target, id string) {
+	t.Helper()
+	compare(t, PickOrder, func() *http.Request {
+		body := strings.NewReader("note=hi")
+		r := httptest.NewRequest("POST", target, body)
+		r.Header.Set("content-type", "application/x-www-form-urlencoded")
+		r.Header.Set("x-trace", "abc")
+		r.AddCookie(&http.Cookie{Name: "session", Value: "s"})
+		r.SetPathValue("id", id)
+		return r
+	})
+}
+
+func TestPickSearch(t *testing.T) {
+	cases := []string{
+		"/?text=a&page=2",
+		"/?text=&page=",
+		"/?page=2",
+		"/?text=a&page=-1",
+		"/?text=a&page=18446744073709551616",
+	}
+	for _, target := range cases {
+		for _, trace := range []string{"", "abc"} {
+			compare(t, PickSearch, func() *http.Request {
+				r := httptest.NewRequest("GET", target, http.NoBody)
+				r.Header.Set("x-trace", trace)
+				return r
+			})
+		}
+		compare(t, PickSearch, func() *http.Request {
+			return httptest.NewRequest("GET", target, http.NoBody)
+		})
+	}
+}
+
+// compare picks the same request with the generated pick func and
+// package xr, expecting the same result.
+func compare[T any](t *testing.T, pick func(*T, *http.Request) error,
+	newRequest func() *http.Request,
+) {
+	t.Helper()
+	var got, exp T
+	err := pick(&got, newRequest())
+	expErr := xr.NewPicker().Pick(&exp, newRequest())
+	r := newRequest()
+	if fmt.Sprint(err) != fmt.Sprint(expErr) {
+		t.Errorf("%s %v\ngot %v\nexp %v", r.URL, r.Header, err, expErr)
+	}
+	if got, exp := code(err), code(expErr); got != exp {
+		t.Errorf("%s %v code\ngot %q\nexp %q", r.URL, r.Header, got, exp)
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("%s %v\ngot %+v\nexp %+v", r.URL, r.Header, got, exp)
+	}
+}
+
+func code(err error) string {
+	var e *xr.PickError
+	if !errors.As(err, &e) {
+		return ""
+	}
+	return e.Code
+}
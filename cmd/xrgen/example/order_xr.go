@@ -0,0 +1,214 @@
+// Code generated by xrgen; DO NOT EDIT.
+
+package example
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gregoryv/xr"
+)
+
+// PickOrder picks the tagged fields of Order from r.
+func PickOrder(dst *Order, r *http.Request) error {
+	query := r.URL.Query()
+	for _, pick := range []func(*Order, *http.Request, url.Values) error{
+		pickOrderID,
+		pickOrderLimit,
+		pickOrderPrice,
+		pickOrderExpress,
+		pickOrderTrace,
+		pickOrderSession,
+		pickOrderNote,
+	} {
+		if err := pick(dst, r, query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func pickOrderID(
+	dst *Order, r *http.Request, query url.Values,
+) error {
+	v := r.PathValue("id")
+	found := v != ""
+	if !found {
+		return xr.NewPickError(
+			"ID", "path[id]", xr.ErrRequired,
+		)
+	}
+	if v == "" {
+		return nil
+	}
+	value, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return xr.NewPickError(
+			"ID", "path[id]", err,
+		)
+	}
+	dst.ID = int(value)
+	return nil
+}
+
+func pickOrderLimit(
+	dst *Order, r *http.Request, query url.Values,
+) error {
+	v := query.Get("limit")
+	_, found := query["limit"]
+	if !found || v == "" {
+		return nil
+	}
+	value, err := strconv.ParseUint(v, 10, 8)
+	if err != nil {
+		return xr.NewPickError(
+			"Limit", "query[limit]", err,
+		)
+	}
+	dst.Limit = uint8(value)
+	return nil
+}
+
+func pickOrderPrice(
+	dst *Order, r *http.Request, query url.Values,
+) error {
+	v := query.Get("price")
+	_, found := query["price"]
+	if !found || v == "" {
+		return nil
+	}
+	value, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return xr.NewPickError(
+			"Price", "query[price]", err,
+		)
+	}
+	dst.Price = value
+	return nil
+}
+
+func pickOrderExpress(
+	dst *Order, r *http.Request, query url.Values,
+) error {
+	v := query.Get("express")
+	_, found := query["express"]
+	if !found || v == "" {
+		return nil
+	}
+	value, err := strconv.ParseBool(v)
+	if err != nil {
+		return xr.NewPickError(
+			"Express", "query[express]", err,
+		)
+	}
+	dst.Express = value
+	return nil
+}
+
+func pickOrderTrace(
+	dst *Order, r *http.Request, query url.Values,
+) error {
+	v := r.Header.Get("x-trace")
+	found := len(r.Header.Values("x-trace")) > 0
+	if !found || v == "" {
+		return nil
+	}
+	dst.Trace = v
+	return nil
+}
+
+func pickOrderSession(
+	dst *Order, r *http.Request, query url.Values,
+) error {
+	var v string
+	c, err := r.Cookie("session")
+	found := err == nil
+	if found {
+		v = c.Value
+	}
+	if !found || v == "" {
+		return nil
+	}
+	dst.Session = v
+	return nil
+}
+
+func pickOrderNote(
+	dst *Order, r *http.Request, query url.Values,
+) error {
+	v := r.FormValue("note")
+	_, found := r.Form["note"]
+	if !found || v == "" {
+		return nil
+	}
+	dst.Note = v
+	return nil
+}
+
+// PickSearch picks the tagged fields of Search from r.
+func PickSearch(dst *Search, r *http.Request) error {
+	query := r.URL.Query()
+	for _, pick := range []func(*Search, *http.Request, url.Values) error{
+		pickSearchText,
+		pickSearchPage,
+		pickSearchTrace,
+	} {
+		if err := pick(dst, r, query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func pickSearchText(
+	dst *Search, r *http.Request, query url.Values,
+) error {
+	v := query.Get("text")
+	_, found := query["text"]
+	if !found {
+		return xr.NewPickError(
+			"Text", "query[text]", xr.ErrRequired,
+		)
+	}
+	if v == "" {
+		return nil
+	}
+	dst.Text = v
+	return nil
+}
+
+func pickSearchPage(
+	dst *Search, r *http.Request, query url.Values,
+) error {
+	v := query.Get("page")
+	_, found := query["page"]
+	if !found || v == "" {
+		return nil
+	}
+	value, err := strconv.ParseUint(v, 10, strconv.IntSize)
+	if err != nil {
+		return xr.NewPickError(
+			"Page", "query[page]", err,
+		)
+	}
+	dst.Page = uint(value)
+	return nil
+}
+
+func pickSearchTrace(
+	dst *Search, r *http.Request, query url.Values,
+) error {
+	v := r.Header.Get("x-trace")
+	found := len(r.Header.Values("x-trace")) > 0
+	if !found {
+		return xr.NewPickError(
+			"Trace", "header[x-trace]", xr.ErrRequired,
+		)
+	}
+	if v == "" {
+		return nil
+	}
+	dst.Trace = v
+	return nil
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/gregoryv/xr"
+)
+
+// generate returns the source of pick funcs for all annotated
+// structs in src.
+func generate(filename string, src []byte) ([]byte, error) {
+	f, err := parser.ParseFile(
+		token.NewFileSet(), filename, src, parser.ParseComments,
+	)
+	if err != nil {
+		return nil, err
+	}
+	g := gen{Package: f.Name.Name}
+	if err := g.addStructs(annotated(f)); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, g); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// annotated returns struct types of f with the //xr:pick comment.
+func annotated(f *ast.File) []*ast.TypeSpec {
+	var specs []*ast.TypeSpec
+	for _, decl := range f.Decls {
+		if d, ok := decl.(*ast.GenDecl); ok && d.Tok == token.TYPE {
+			specs = append(specs, annotatedSpecs(d)...)
+		}
+	}
+	return specs
+}
+
+// annotatedSpecs returns type specs of d with the //xr:pick
+// comment, on the spec or on a declaration of a single type.
+func annotatedSpecs(d *ast.GenDecl) []*ast.TypeSpec {
+	var specs []*ast.TypeSpec
+	for _, spec := range d.Specs {
+		s := spec.(*ast.TypeSpec)
+		single := !d.Lparen.IsValid() && hasAnnotation(d.Doc)
+		if single || hasAnnotation(s.Doc) {
+			specs = append(specs, s)
+		}
+	}
+	return specs
+}
+
+func hasAnnotation(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+	for _, c := range doc.List {
+		if c.Text == "//xr:pick" {
+			return true
+		}
+	}
+	return false
+}
+
+type gen struct {
+	Package string
+	Structs []structInfo
+	Strconv bool
+}
+
+type structInfo struct {
+	Name   string
+	Fields []fieldInfo
+}
+
+// HasQuery returns true if any field is read from the query.
+func (s structInfo) HasQuery() bool {
+	for _, f := range s.Fields {
+		if f.Kind == "query" {
+			return true
+		}
+	}
+	return false
+}
+
+type fieldInfo struct {
+	Name     string
+	Kind     string // source, e.g. query
+	Key      string // name in source
+	Required bool
+	Parse    string // expression of v, e.g. strconv.ParseBool(v)
+	Cast     string // type converting the parsed value, if needed
+}
+
+func (g *gen) addStructs(specs []*ast.TypeSpec) error {
+	if len(specs) == 0 {
+		return fmt.Errorf("no struct annotated with //xr:pick")
+	}
+	for _, spec := range specs {
+		if err := g.addStruct(spec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *gen) addStruct(spec *ast.TypeSpec) error {
+	st, ok := spec.Type.(*ast.StructType)
+	if !ok {
+		return fmt.Errorf("%s: not a struct", spec.Name.Name)
+	}
+	s := structInfo{Name: spec.Name.Name}
+	for _, field := range st.Fields.List {
+		fields, err := g.fields(field)
+		if err != nil {
+			return fmt.Errorf("%s.%w", s.Name, err)
+		}
+		s.Fields = append(s.Fields, fields...)
+	}
+	g.Structs = append(g.Structs, s)
+	return nil
+}
+
+// fields returns one field info per name of field tagged with a
+// source.
+func (g *gen) fields(field *ast.Field) ([]fieldInfo, error) {
+	kind, key := source(field.Tag)
+	if kind == "" {
+		return nil, nil
+	}
+	typ := types.ExprString(field.Type)
+	p, found := parsers[typ]
+	if !found {
+		return nil, fmt.Errorf("%s: unsupported type %s", names(field), typ)
+	}
+	g.Strconv = g.Strconv || p.Parse != ""
+	var fields []fieldInfo
+	for _, name := range field.Names {
+		f := p
+		f.Name, f.Kind, f.Key = name.Name, kind, keyOf(name.Name, key)
+		f.Required = tagOf(field.Tag).Get("required") == "true"
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// keyOf returns key, or the name of the field as named by the
+// default naming of xr if the source tag has no name, e.g.
+// `query:""`.
+func keyOf(name, key string) string {
+	if key == "" {
+		return xr.LowerCamelCase(name)
+	}
+	return key
+}
+
+func names(field *ast.Field) string {
+	var s []string
+	for _, name := range field.Names {
+		s = append(s, name.Name)
+	}
+	return strings.Join(s, ",")
+}
+
+// source returns the first source tag and its name, which may be
+// empty.
+func source(lit *ast.BasicLit) (string, string) {
+	tag := tagOf(lit)
+	for _, kind := range sources {
+		if key, found := tag.Lookup(kind); found {
+			return kind, key
+		}
+	}
+	return "", ""
+}
+
+var sources = []string{"path", "query", "header", "cookie", "form"}
+
+func tagOf(lit *ast.BasicLit) reflect.StructTag {
+	if lit == nil {
+		return ""
+	}
+	tag, _ := strconv.Unquote(lit.Value)
+	return reflect.StructTag(tag)
+}
+
+// parsers of supported field types
+var parsers = map[string]fieldInfo{
+	"string": {},
+	"bool":   {Parse: "strconv.ParseBool(v)"},
+	"int":    {Parse: "strconv.ParseInt(v, 10, 64)", Cast: "int"},
+	"int8":   {Parse: "strconv.ParseInt(v, 10, 8)", Cast: "int8"},
+	"int16":  {Parse: "strconv.ParseInt(v, 10, 16)", Cast: "int16"},
+	"int32":  {Parse: "strconv.ParseInt(v, 10, 32)", Cast: "int32"},
+	"int64":  {Parse: "strconv.ParseInt(v, 10, 64)"},
+	"uint": {
+		Parse: "strconv.ParseUint(v, 10, strconv.IntSize)", Cast: "uint",
+	},
+	"uint8":   {Parse: "strconv.ParseUint(v, 10, 8)", Cast: "uint8"},
+	"uint16":  {Parse: "strconv.ParseUint(v, 10, 16)", Cast: "uint16"},
+	"uint32":  {Parse: "strconv.ParseUint(v, 10, 32)", Cast: "uint32"},
+	"uint64":  {Parse: "strconv.ParseUint(v, 10, 64)"},
+	"float32": {Parse: "strconv.ParseFloat(v, 32)", Cast: "float32"},
+	"float64": {Parse: "strconv.ParseFloat(v, 64)"},
+}
+
+// readers of values by source, setting v to the first value and
+// found if there is one, even if empty
+var readers = map[string]string{
+	"path": `v := r.PathValue(%[1]q)
+	found := v != ""`,
+	"query": `v := query.Get(%[1]q)
+	_, found := query[%[1]q]`,
+	"header": `v := r.Header.Get(%[1]q)
+	found := len(r.Header.Values(%[1]q)) > 0`,
+	"cookie": `var v string
+	c, err := r.Cookie(%[1]q)
+	found := err == nil
+	if found {
+		v = c.Value
+	}`,
+	"form": `v := r.FormValue(%[1]q)
+	_, found := r.Form[%[1]q]`,
+}
+
+var tmpl = template.Must(template.New("").Funcs(template.FuncMap{
+	"read": func(f fieldInfo) string {
+		return fmt.Sprintf(readers[f.Kind], f.Key)
+	},
+}).Parse(`// Code generated by xrgen; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"net/http"
+	"net/url"
+	{{- if .Strconv}}
+	"strconv"
+	{{- end}}
+
+	"github.com/gregoryv/xr"
+)
+{{range $s := .Structs}}
+// Pick{{.Name}} picks the tagged fields of {{.Name}} from r.
+func Pick{{.Name}}(dst *{{.Name}}, r *http.Request) error {
+	{{- if .HasQuery}}
+	query := r.URL.Query()
+	{{- else}}
+	var query url.Values
+	{{- end}}
+	for _, pick := range []func(*{{.Name}}, *http.Request, url.Values) error{
+		{{- range .Fields}}
+		pick{{$s.Name}}{{.Name}},
+		{{- end}}
+	} {
+		if err := pick(dst, r, query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+{{range .Fields}}
+func pick{{$s.Name}}{{.Name}}(
+	dst *{{$s.Name}}, r *http.Request, query url.Values,
+) error {
+	{{read .}}
+	{{- if .Required}}
+	if !found {
+		return xr.NewPickError(
+			"{{.Name}}", "{{.Kind}}[{{.Key}}]", xr.ErrRequired,
+		)
+	}
+	{{- end}}
+	if {{if not .Required}}!found || {{end}}v == "" {
+		return nil
+	}
+	{{- if .Parse}}
+	value, err := {{.Parse}}
+	if err != nil {
+		return xr.NewPickError(
+			"{{.Name}}", "{{.Kind}}[{{.Key}}]", err,
+		)
+	}
+	dst.{{.Name}} = {{with .Cast}}{{.}}(value){{else}}value{{end}}
+	{{- else}}
+	dst.{{.Name}} = v
+	{{- end}}
+	return nil
+}
+{{end}}{{end}}`))
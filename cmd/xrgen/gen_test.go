@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerate_example(t *testing.T) {
+	src, _ := os.ReadFile("example/order.go")
+	got, err := generate("order.go", src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp, _ := os.ReadFile("example/order_xr.go")
+	if string(got) != string(exp) {
+		t.Error("example/order_xr.go is stale, run go generate ./...")
+	}
+}
+
+func TestGenerate_errors(t *testing.T) {
+	cases := map[string]string{
+		"syntax": "package x\ntype",
+		"none":   "package x\ntype T struct{}",
+		"struct": "package x\n//xr:pick\ntype T int",
+		"type": "package x\n//xr:pick\n" +
+			"type T struct{ A, B []int `query:\"a\"` }",
+	}
+	for name, src := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := generate("x.go", []byte(src)); err == nil {
+				t.Error("expect error")
+			}
+		})
+	}
+}
+
+func TestGenerate_group(t *testing.T) {
+	src := "package x\ntype (\n//xr:pick\nA struct{}\nB struct{}\n)"
+	got, err := generate("x.go", []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(got), "PickA") ||
+		strings.Contains(string(got), "PickB") {
+		t.Error(string(got))
+	}
+}
+
+func Test_run(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "x.go")
+	src := "package x\n//xr:pick\ntype T struct{}"
+	_ = os.WriteFile(file, []byte(src), 0o644)
+	if err := run(file, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "x_xr.go")); err != nil {
+		t.Error(err)
+	}
+	if err := run(filepath.Join(dir, "missing.go"), ""); err == nil {
+		t.Error("expect error for missing file")
+	}
+	if err := run(file, filepath.Join(dir, "no", "x.go")); err == nil {
+		t.Error("expect error for bad output")
+	}
+}
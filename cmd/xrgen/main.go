@@ -0,0 +1,62 @@
+// Command xrgen generates funcs picking structs from requests
+// without reflection.
+//
+// Structs annotated with //xr:pick get a func PickT(dst *T, r
+// *http.Request) error setting fields tagged path, query, header,
+// cookie or form, e.g.
+//
+//	//go:generate go run github.com/gregoryv/xr/cmd/xrgen
+//
+//	//xr:pick
+//	type Order struct {
+//		ID    int    `path:"id" required:"true"`
+//		Trace string `header:"x-trace"`
+//	}
+//
+// Fields must be of type string, bool or a numeric type; other
+// fields, and field tags, are ignored. Source tags without a name,
+// e.g. `query:""`, use the field name as named by
+// xr.LowerCamelCase, the default naming of package xr. Errors are
+// *xr.PickError, the same as when picking with package xr.
+//
+// Usage
+//
+//	xrgen [-o output] [file.go]
+//
+// The file defaults to $GOFILE, set by go generate, and the output
+// to file_xr.go.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"strings"
+)
+
+func main() {
+	out := flag.String("o", "", "output file, default file_xr.go")
+	flag.Parse()
+	file := flag.Arg(0)
+	if file == "" {
+		file = os.Getenv("GOFILE")
+	}
+	if err := run(file, *out); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(file, out string) error {
+	src, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	code, err := generate(file, src)
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		out = strings.TrimSuffix(file, ".go") + "_xr.go"
+	}
+	return os.WriteFile(out, code, 0o644)
+}
@@ -0,0 +1,101 @@
+package xr
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// CSVDecoder returns a decoder of r appending one element per record
+// to the []T field tagged `body:"csv"`, where T is a struct. The
+// first record is a header, its columns are set on fields of T with
+// the same csv tag, e.g. `csv:"name"`, using the setters, checks and
+// mappings of p. Register it with
+//
+//	p.Register("text/csv", p.CSVDecoder)
+func (p *Picker) CSVDecoder(r io.Reader) Decoder {
+	return decoderFunc(func(v any) error {
+		return p.decodeCSV(csv.NewReader(r), v)
+	})
+}
+
+func (p *Picker) decodeCSV(r *csv.Reader, v any) error {
+	field := p.bodyField(v, "csv")
+	if !field.IsValid() {
+		return nil
+	}
+	if !isRecords(field.Type()) {
+		return unsupportedBody("csv", field)
+	}
+	header, err := r.Read()
+	if err != nil {
+		return ignoreEOF(err)
+	}
+	columns := p.csvColumns(field.Type().Elem(), header)
+	return p.readRecords(r, field, columns)
+}
+
+// readRecords appends all remaining records of r to field.
+func (p *Picker) readRecords(r *csv.Reader, field reflect.Value,
+	columns []int,
+) error {
+	for {
+		record, err := r.Read()
+		if err != nil {
+			return ignoreEOF(err)
+		}
+		if err := p.appendRecord(field, columns, record); err != nil {
+			line, _ := r.FieldPos(0)
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+}
+
+func ignoreEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return err
+}
+
+// isRecords returns true if t is a slice of structs.
+func isRecords(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Struct
+}
+
+// csvColumns returns the field index of each header column in
+// struct t, or -1 if there is no such field. Tags of a mapping are
+// used if any.
+func (p *Picker) csvColumns(t reflect.Type, header []string) []int {
+	plan := p.planOf(t)
+	columns := make([]int, len(header))
+	for i, name := range header {
+		columns[i] = -1
+		for j := range plan {
+			if plan[j].Tag.Get("csv") == name {
+				columns[i] = j
+			}
+		}
+	}
+	return columns
+}
+
+// appendRecord sets the columns of record on a new element appended
+// to the slice field.
+func (p *Picker) appendRecord(field reflect.Value, columns []int,
+	record []string,
+) error {
+	obj := reflect.New(field.Type().Elem())
+	for i, j := range columns {
+		if j < 0 || i >= len(record) {
+			continue
+		}
+		if err := p.setValue(obj, j, record[i], bodySource); err != nil {
+			return err
+		}
+	}
+	field.Set(reflect.Append(field, obj.Elem()))
+	return nil
+}
@@ -0,0 +1,93 @@
+package xr
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func ExamplePicker_CSVDecoder() {
+	type Row struct {
+		Name string `csv:"name"`
+		Age  int    `csv:"age"`
+	}
+	var x struct {
+		Team string `query:"team"`
+		Rows []Row  `body:"csv"`
+	}
+	body := strings.NewReader("age,name,note\n7,John,x\n9,Eva,y\n")
+	r := httptest.NewRequest("POST", "/import?team=a", body)
+	r.Header.Set("content-type", "text/csv")
+	_ = Pick(&x, r)
+	fmt.Println(x.Team, x.Rows)
+	// output:
+	// a [{John 7} {Eva 9}]
+}
+
+func TestPicker_CSVDecoder(t *testing.T) {
+	type Row struct {
+		Age int `csv:"age" minimum:"0"`
+	}
+	cases := map[string]string{
+		"invalid": "age\nseven\n",
+		"check":   "age\n1\n-1\n",
+		"columns": "age\n1,2\n",
+	}
+	for name, data := range cases {
+		t.Run(name, func(t *testing.T) {
+			var x struct {
+				Rows []Row `body:"csv"`
+			}
+			r := httptest.NewRequest("POST", "/", strings.NewReader(data))
+			r.Header.Set("content-type", "text/csv")
+			if err := Pick(&x, r); err == nil {
+				t.Error("expect error")
+			}
+		})
+	}
+}
+
+func TestPicker_CSVDecoder_unsupported(t *testing.T) {
+	var x struct {
+		Rows []string `body:"csv"`
+	}
+	r := httptest.NewRequest("POST", "/", strings.NewReader("a\nb\n"))
+	r.Header.Set("content-type", "text/csv")
+	if err := Pick(&x, r); err == nil {
+		t.Error("expect error")
+	}
+}
+
+func TestPicker_CSVDecoder_empty(t *testing.T) {
+	var x struct {
+		Rows []struct{} `body:"csv"`
+	}
+	r := httptest.NewRequest("POST", "/", strings.NewReader(""))
+	r.Header.Set("content-type", "text/csv")
+	if err := Pick(&x, r); err != nil || x.Rows != nil {
+		t.Error(x, err)
+	}
+}
+
+func TestPicker_CSVDecoder_mapping(t *testing.T) {
+	type Row struct {
+		Name string
+	}
+	type Import struct {
+		Rows []Row
+	}
+	p := NewPicker()
+	p.Register("text/csv", p.CSVDecoder)
+	p.UseMapping(Row{}, NewMapping().Field("Name", `csv:"name"`))
+	p.UseMapping(Import{}, NewMapping().Field("Rows", `body:"csv"`))
+	var x Import
+	r := httptest.NewRequest("POST", "/", strings.NewReader("name\nJohn\n"))
+	r.Header.Set("content-type", "text/csv")
+	if err := p.Pick(&x, r); err != nil || len(x.Rows) != 1 {
+		t.Fatal(x, err)
+	}
+	if x.Rows[0].Name != "John" {
+		t.Error(x.Rows)
+	}
+}
@@ -0,0 +1,69 @@
+package xr
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// RegisterContextKey names a request context key for field tag ctx,
+// e.g. values put in the context by middleware
+//
+//	p.RegisterContextKey("userID", userIDKey{})
+//
+// are picked into fields tagged `ctx:"userID"`. Safe to call while
+// picking. Panics if the name is already registered.
+func (p *Picker) RegisterContextKey(name string, key any) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, found := p.contextKeys[name]; found {
+		panic(fmt.Sprintf("RegisterContextKey(%q): already exists", name))
+	}
+	p.contextKeys[name] = key
+}
+
+func isContextField(field reflect.StructField) bool {
+	_, found := field.Tag.Lookup("ctx")
+	return found
+}
+
+// pickContext sets field i of obj to the value of the request
+// context with the key named by its ctx tag. Values assignable to
+// the field are set as is, strings are set like other source values.
+// Panics on unknown names.
+func (p *Picker) pickContext(
+	obj reflect.Value, i int, r *http.Request,
+) error {
+	field := p.field(obj, i)
+	if !field.IsExported() {
+		panic(misuse("%v: private", field.Name))
+	}
+	src := source{kind: "ctx", name: field.Tag.Get("ctx")}
+	key, found := lookup(p, p.contextKeys, src.name)
+	if !found {
+		panic(misuse("%v: unknown", src))
+	}
+	v := reflect.ValueOf(r.Context().Value(key))
+	if !v.IsValid() {
+		return p.pickMissing(obj, i, src)
+	}
+	return p.setContextValue(obj, i, v, src)
+}
+
+// setContextValue sets and validates field i of obj to v, assigned
+// or set from a string.
+func (p *Picker) setContextValue(obj reflect.Value, i int,
+	v reflect.Value, src source,
+) error {
+	field := p.field(obj, i)
+	switch {
+	case v.Type().AssignableTo(field.Type):
+		obj.Elem().Field(i).Set(v)
+		return p.validate(obj, i, src)
+
+	case v.Kind() == reflect.String:
+		return p.setValue(obj, i, v.String(), src)
+	}
+	err := fmt.Errorf("%v: not assignable to %v", v.Type(), field.Type)
+	return NewPickError(field.Name, src.String(), err)
+}
@@ -0,0 +1,71 @@
+package xr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type userIDKey struct{}
+
+func ExamplePicker_RegisterContextKey() {
+	p := NewPicker()
+	p.RegisterContextKey("userID", userIDKey{})
+
+	var x struct {
+		UserID int `ctx:"userID" required:"true"`
+	}
+	r := httptest.NewRequest("GET", "/", http.NoBody)
+	ctx := context.WithValue(r.Context(), userIDKey{}, 42)
+	_ = p.Pick(&x, r.WithContext(ctx))
+	fmt.Println(x.UserID)
+
+	fmt.Println(p.Pick(&x, r))
+	// output:
+	// 42
+	// pick UserID from ctx[userID]: required
+}
+
+func TestPicker_RegisterContextKey_duplicate(t *testing.T) {
+	defer catchPanic(t)
+	p := NewPicker()
+	p.RegisterContextKey("userID", userIDKey{})
+	p.RegisterContextKey("userID", userIDKey{})
+}
+
+func TestPicker_pickContext(t *testing.T) {
+	p := NewPicker()
+	p.RegisterContextKey("userID", userIDKey{})
+	var x struct {
+		UserID int `ctx:"userID" minimum:"1"`
+	}
+	cases := []struct {
+		value any
+		ok    bool
+	}{
+		{7, true},
+		{"7", true},
+		{0, false},
+		{"seven", false},
+		{7.0, false},
+	}
+	for _, c := range cases {
+		r := httptest.NewRequest("GET", "/", http.NoBody)
+		ctx := context.WithValue(r.Context(), userIDKey{}, c.value)
+		err := p.Pick(&x, r.WithContext(ctx))
+		if (err == nil) != c.ok {
+			t.Errorf("%#v: %v", c.value, err)
+		}
+	}
+}
+
+func TestPicker_pickContext_unknown(t *testing.T) {
+	defer catchPanic(t)
+	var x struct {
+		UserID int `ctx:"userID"`
+	}
+	r := httptest.NewRequest("GET", "/", http.NoBody)
+	_ = NewPicker().Pick(&x, r)
+}
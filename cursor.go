@@ -0,0 +1,49 @@
+package xr
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// Cursor is an opaque pagination token of base64 encoded JSON
+// holding a value of T, e.g.
+//
+//	type Page struct { After int }
+//	var x struct {
+//		Cursor xr.Cursor[Page] `query:"cursor"`
+//	}
+//
+// Use NewCursor for producing the next token.
+type Cursor[T any] struct {
+	Value T
+}
+
+// NewCursor returns a cursor of v.
+func NewCursor[T any](v T) Cursor[T] {
+	return Cursor[T]{Value: v}
+}
+
+// String returns the token of c, or empty if the value cannot be
+// encoded.
+func (c Cursor[T]) String() string {
+	token, _ := c.MarshalText()
+	return string(token)
+}
+
+// MarshalText returns the token of c.
+func (c Cursor[T]) MarshalText() ([]byte, error) {
+	data, err := json.Marshal(c.Value)
+	if err != nil {
+		return nil, err
+	}
+	return base64.RawURLEncoding.AppendEncode(nil, data), nil
+}
+
+// UnmarshalText decodes token into c.
+func (c *Cursor[T]) UnmarshalText(token []byte) error {
+	data, err := base64.RawURLEncoding.AppendDecode(nil, token)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &c.Value)
+}
@@ -0,0 +1,40 @@
+package xr
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExampleCursor() {
+	type Page struct {
+		After int `json:"after"`
+	}
+	var x struct {
+		Cursor Cursor[Page] `query:"cursor"`
+	}
+	next := NewCursor(Page{After: 42}).String()
+	r := httptest.NewRequest("GET", "/items?cursor="+next, nil)
+	_ = Pick(&x, r)
+	fmt.Println(next, x.Cursor.Value.After)
+	// output:
+	// eyJhZnRlciI6NDJ9 42
+}
+
+func TestCursor_invalid(t *testing.T) {
+	var x struct {
+		Cursor Cursor[int] `query:"cursor"`
+	}
+	for _, token := range []string{"not*base64", "eyJhIjox"} {
+		r := httptest.NewRequest("GET", "/?cursor="+token, nil)
+		if err := Pick(&x, r); err == nil {
+			t.Error("expect error for", token)
+		}
+	}
+}
+
+func TestCursor_String(t *testing.T) {
+	if got := NewCursor(func() {}).String(); got != "" {
+		t.Error(got)
+	}
+}
@@ -0,0 +1,94 @@
+package xr
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// isDeepObject returns true if field is a map with string keys
+// tagged query, picked from parameters in OpenAPI deepObject style,
+// e.g. ?filter[status]=active.
+func (p *Picker) isDeepObject(field reflect.StructField) bool {
+	_, found := p.tagName(field, "query")
+	t := field.Type
+	return found && t.Kind() == reflect.Map &&
+		t.Key().Kind() == reflect.String
+}
+
+// pickDeepObject sets map field i of obj to the query parameters
+// NAME[KEY] where NAME is the name of the query tag.
+func (p *Picker) pickDeepObject(
+	obj reflect.Value, i int, r *http.Request,
+) error {
+	field := p.field(obj, i)
+	if !field.IsExported() {
+		panic(misuse("%v: private", field.Name))
+	}
+	name, _ := p.tagName(field, "query")
+	values := p.deepObject(r, name)
+	if len(values) == 0 {
+		return nil
+	}
+	src := source{kind: "query", name: name}
+	m, err := p.makeMap(field, values)
+	if err != nil {
+		return NewPickError(field.Name, src.String(), err)
+	}
+	obj.Elem().Field(i).Set(m)
+	return p.validate(obj, i, src)
+}
+
+// makeMap returns a map of the field type with values set by key.
+func (p *Picker) makeMap(
+	field reflect.StructField, values map[string]string,
+) (reflect.Value, error) {
+	elem := field
+	elem.Type = field.Type.Elem()
+	m := reflect.MakeMapWithSize(field.Type, len(values))
+	for _, key := range sortedKeys(values) {
+		v := reflect.New(elem.Type).Elem()
+		if err := p.setTo(elem, v, values[key]); err != nil {
+			return m, fmt.Errorf("%s: %w", key, err)
+		}
+		k := reflect.ValueOf(key).Convert(field.Type.Key())
+		m.SetMapIndex(k, v)
+	}
+	return m, nil
+}
+
+// deepObject returns the first value of each parameter NAME[KEY] by
+// KEY. Values are read with the query reader, i.e. NAME may match
+// regardless of case, see SetCaseInsensitiveQuery.
+func (p *Picker) deepObject(r *http.Request, name string) map[string]string {
+	values := make(map[string]string)
+	for param := range queryOf(r) {
+		key, found := deepKey(param, name)
+		if !found {
+			continue
+		}
+		if all := p.readers["query"](r, name+"["+key+"]"); len(all) > 0 {
+			values[key] = all[0]
+		}
+	}
+	return values
+}
+
+// deepKey returns KEY of parameter NAME[KEY] if NAME equals name
+// under case folding.
+func deepKey(param, name string) (string, bool) {
+	prefix, key, found := strings.Cut(param, "[")
+	key, closed := strings.CutSuffix(key, "]")
+	return key, found && closed && strings.EqualFold(prefix, name)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,58 @@
+package xr
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExamplePick_deepObject() {
+	var x struct {
+		Filter map[string]string `query:"filter"`
+		Range  map[string]int    `query:"range"`
+	}
+	r := httptest.NewRequest(
+		"GET", "/?filter[status]=active&range[min]=1&range[max]=9", nil,
+	)
+	_ = Pick(&x, r)
+	fmt.Println(x.Filter, x.Range)
+	// output:
+	// map[status:active] map[max:9 min:1]
+}
+
+func TestPick_deepObjectError(t *testing.T) {
+	var x struct {
+		Range map[string]int `query:"range"`
+	}
+	r := httptest.NewRequest("GET", "/?range[min]=one", nil)
+	if err := Pick(&x, r); err == nil {
+		t.Error("expect error")
+	}
+}
+
+func TestPick_deepObjectMissing(t *testing.T) {
+	var x struct {
+		Filter map[string]string `query:"filter"`
+	}
+	r := httptest.NewRequest("GET", "/?filter=x&filter[a=1", nil)
+	if err := Pick(&x, r); err != nil || x.Filter != nil {
+		t.Error(x.Filter, err)
+	}
+}
+
+func TestPick_deepObjectCaseInsensitive(t *testing.T) {
+	type Search struct {
+		Filter map[string]string
+	}
+	p := NewPicker()
+	p.UseMapping(Search{}, NewMapping().Field("Filter", `query:"filter"`))
+	r := httptest.NewRequest("GET", "/?Filter[status]=active", nil)
+	var x Search
+	if err := p.Pick(&x, r); err != nil || x.Filter != nil {
+		t.Error(x.Filter, err)
+	}
+	p.SetCaseInsensitiveQuery(true)
+	if err := p.Pick(&x, r); err != nil || x.Filter["status"] != "active" {
+		t.Error(x.Filter, err)
+	}
+}
@@ -4,38 +4,122 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"reflect"
 )
 
 func init() {
 	p := NewPicker()
-	p.Register("application/json",
-		func(r io.Reader) Decoder {
-			return json.NewDecoder(r)
-		},
-	)
-	p.Register("application/json; charset=utf-8",
-		func(r io.Reader) Decoder {
-			return json.NewDecoder(r)
-		},
+	p.Register("application/json", JSONDecoder)
+	p.Register("text/plain", p.TextDecoder(DefaultTextBytes))
+	p.Register(
+		"application/octet-stream", p.BinaryDecoder(DefaultBinaryBytes),
 	)
+	p.Register("text/csv", p.CSVDecoder)
+	p.RegisterEncoder("application/json", JSONEncoder)
 	PickerDefault = p
 }
 
+// JSONDecoder returns a json.Decoder of r.
+func JSONDecoder(r io.Reader) Decoder {
+	return json.NewDecoder(r)
+}
+
+// JSONEncoder returns a json.Encoder of w.
+func JSONEncoder(w io.Writer) Encoder {
+	return json.NewEncoder(w)
+}
+
+// StrictJSONDecoder returns a json.Decoder of r rejecting unknown
+// fields, e.g.
+//
+//	p.Register("application/json", StrictJSONDecoder)
+func StrictJSONDecoder(r io.Reader) Decoder {
+	d := json.NewDecoder(r)
+	d.DisallowUnknownFields()
+	return d
+}
+
 // Pick using [PickerDefault]
 func Pick(dst any, r *http.Request) error {
 	return PickerDefault.Pick(dst, r)
 }
 
+// PickInto using [PickerDefault]
+func PickInto(dst any, r *http.Request, opts ...PickOption) error {
+	return PickerDefault.PickInto(dst, r, opts...)
+}
+
+// NewRequest using [PickerDefault]
+func NewRequest(method, url string, src any) (*http.Request, error) {
+	return PickerDefault.NewRequest(method, url, src)
+}
+
 // Register using [PickerDefault]
 func Register(contentType string, fn func(io.Reader) Decoder) {
 	PickerDefault.Register(contentType, fn)
 }
 
+// RegisterEncoder using [PickerDefault]
+func RegisterEncoder(contentType string, fn func(io.Writer) Encoder) {
+	PickerDefault.RegisterEncoder(contentType, fn)
+}
+
+// Respond using [PickerDefault]
+func Respond(w http.ResponseWriter, r *http.Request, status int,
+	v any,
+) error {
+	return PickerDefault.Respond(w, r, status, v)
+}
+
 // UseSetter using [PickerDefault]
+//
+// Deprecated: use UseTypeSetter or UseSetterFor.
 func UseSetter(typ string, fn setfn) {
 	PickerDefault.UseSetter(typ, fn)
 }
 
-// PickerDefault has a predefined content-type decoder for
-// application/json.
+// UseTypeSetter using [PickerDefault]
+func UseTypeSetter(t reflect.Type,
+	fn func(field reflect.Value, v string) error,
+) {
+	PickerDefault.UseTypeSetter(t, fn)
+}
+
+// RegisterFormat using [PickerDefault]
+func RegisterFormat(name string, fn func(string) error) {
+	PickerDefault.RegisterFormat(name, fn)
+}
+
+// Handler using [PickerDefault]
+func Handler(fn HandlerFunc) http.Handler {
+	return PickerDefault.Handler(fn)
+}
+
+// Recover using [PickerDefault]
+func Recover(next http.Handler) http.Handler {
+	return PickerDefault.Recover(next)
+}
+
+// CheckPattern using [PickerDefault]
+func CheckPattern(pattern string, v any) error {
+	return PickerDefault.CheckPattern(pattern, v)
+}
+
+// PickerDefault has predefined content-type decoders for
+// application/json, text/plain and application/octet-stream.
 var PickerDefault *Picker
+
+// RegisterContextKey using [PickerDefault]
+func RegisterContextKey(name string, key any) {
+	PickerDefault.RegisterContextKey(name, key)
+}
+
+// Check using [PickerDefault]
+func Check(v any) error {
+	return PickerDefault.Check(v)
+}
+
+// RegisterValidator using [PickerDefault]
+func RegisterValidator(name string, fn func(value any, arg string) error) {
+	PickerDefault.RegisterValidator(name, fn)
+}
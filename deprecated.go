@@ -0,0 +1,41 @@
+package xr
+
+import (
+	"net/http"
+	"reflect"
+)
+
+// Deprecation describes a value picked into a field tagged with
+// `deprecated:"MESSAGE"`.
+type Deprecation struct {
+	// Field name of the destination
+	Field string
+
+	// Source of the value, e.g. query[id]
+	Source string
+
+	// Message of the deprecated tag, e.g. "use id instead"
+	Message string
+}
+
+// SetDeprecationHook sets fn to be called for each value picked into
+// a field tagged deprecated, e.g. to log or add a response header.
+// Picking does not fail due to deprecated fields.
+func (p *Picker) SetDeprecationHook(fn func(*http.Request, Deprecation)) {
+	p.deprecationHook = fn
+}
+
+// deprecated calls the deprecation hook if field is deprecated.
+func (p *Picker) deprecated(
+	r *http.Request, field reflect.StructField, v value,
+) {
+	msg, found := field.Tag.Lookup("deprecated")
+	if !found || p.deprecationHook == nil {
+		return
+	}
+	p.deprecationHook(r, Deprecation{
+		Field:   field.Name,
+		Source:  v.source().String(),
+		Message: msg,
+	})
+}
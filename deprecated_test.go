@@ -0,0 +1,36 @@
+package xr
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExamplePicker_SetDeprecationHook() {
+	p := NewPicker()
+	p.SetDeprecationHook(func(r *http.Request, d Deprecation) {
+		fmt.Printf("%s %s: %s\n", r.URL.Path, d.Source, d.Message)
+	})
+	var x struct {
+		Id    string `query:"id"`
+		OldId string `query:"oid" deprecated:"use id instead"`
+	}
+	r := httptest.NewRequest("GET", "/items?oid=1", nil)
+	_ = p.Pick(&x, r)
+	fmt.Println(x.OldId)
+	// output:
+	// /items query[oid]: use id instead
+	// 1
+}
+
+func TestPicker_SetDeprecationHook_missing(t *testing.T) {
+	p := NewPicker()
+	p.SetDeprecationHook(func(r *http.Request, d Deprecation) {
+		t.Error("unexpected call", d)
+	})
+	var x struct {
+		OldId string `query:"oid" deprecated:"use id instead"`
+	}
+	_ = p.Pick(&x, httptest.NewRequest("GET", "/", nil))
+}
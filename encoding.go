@@ -0,0 +1,51 @@
+package xr
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// decodeValue returns v decoded by the encoding of field tag
+// encoding, e.g. `encoding:"base64"` for []byte fields or
+// `encoding:"hex"` for [N]byte fields.
+func decodeValue(field reflect.StructField, v string) (string, error) {
+	name, found := field.Tag.Lookup("encoding")
+	if !found {
+		return v, nil
+	}
+	fn, found := encodings[name]
+	if !found {
+		return "", fmt.Errorf("encoding %s: unknown", name)
+	}
+	data, err := fn(v)
+	if err != nil {
+		return "", fmt.Errorf("encoding %s: %w", name, err)
+	}
+	return string(data), nil
+}
+
+// encodings decode values by name. Padding of base64 is optional.
+var encodings = map[string]func(string) ([]byte, error){
+	"base64": func(v string) ([]byte, error) {
+		return base64.RawStdEncoding.DecodeString(strings.TrimRight(v, "="))
+	},
+	"base64url": func(v string) ([]byte, error) {
+		return base64.RawURLEncoding.DecodeString(strings.TrimRight(v, "="))
+	},
+	"hex": hex.DecodeString,
+}
+
+// setByteArrayField sets a [N]byte field to the N bytes of v.
+func setByteArrayField(field reflect.Value, v string) error {
+	if field.Type().Elem().Kind() != reflect.Uint8 {
+		return fmt.Errorf("set %v: unsupported", field.Type())
+	}
+	if len(v) != field.Len() {
+		return fmt.Errorf("got %d bytes, expected %d", len(v), field.Len())
+	}
+	reflect.Copy(field, reflect.ValueOf([]byte(v)))
+	return nil
+}
@@ -0,0 +1,70 @@
+package xr
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func ExamplePick_base64() {
+	var x struct {
+		Signature []byte `header:"X-Signature" encoding:"base64"`
+		Token     []byte `query:"token" encoding:"base64url"`
+	}
+	r := httptest.NewRequest("GET", "/?token=_-8", http.NoBody)
+	r.Header.Set("X-Signature", "aGVsbG8=")
+	_ = Pick(&x, r)
+	fmt.Printf("%s %x\n", x.Signature, x.Token)
+	// output:
+	// hello ffef
+}
+
+func Test_decodeValue(t *testing.T) {
+	cases := []struct {
+		encoding, value string
+		ok              bool
+	}{
+		{"base64", "aGVsbG8", true},
+		{"base64", "+/8=", true},
+		{"base64", "_-8", false},
+		{"base64url", "+/8", false},
+		{"hex", "00ff", true},
+		{"hex", "0g", false},
+		{"base85", "aGVsbG8", false},
+	}
+	for _, c := range cases {
+		field := reflect.StructField{
+			Tag: reflect.StructTag(`encoding:"` + c.encoding + `"`),
+		}
+		if _, err := decodeValue(field, c.value); (err == nil) != c.ok {
+			t.Errorf("%+v: %v", c, err)
+		}
+	}
+}
+
+func ExamplePick_hex() {
+	var x struct {
+		Checksum [4]byte `query:"crc" encoding:"hex"`
+	}
+	r := httptest.NewRequest("GET", "/?crc=cbf43926", http.NoBody)
+	_ = Pick(&x, r)
+	fmt.Println(x.Checksum)
+
+	r = httptest.NewRequest("GET", "/?crc=cbf439", http.NoBody)
+	fmt.Println(Pick(&x, r))
+	// output:
+	// [203 244 57 38]
+	// pick Checksum from query[crc]: got 3 bytes, expected 4
+}
+
+func Test_setByteArrayField_unsupported(t *testing.T) {
+	var x struct {
+		IDs [2]int `query:"ids"`
+	}
+	r := httptest.NewRequest("GET", "/?ids=12", http.NoBody)
+	if err := Pick(&x, r); err == nil {
+		t.Error("expected error")
+	}
+}
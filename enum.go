@@ -0,0 +1,65 @@
+package xr
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// RegisterEnum adds a named table of integer enum values picked by
+// name using field tag enumOf, e.g. `enumOf:"Color"`. Safe to call
+// while picking. Panics if the name is already registered.
+func (p *Picker) RegisterEnum(name string, table map[string]int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, found := p.enums[name]; found {
+		panic(fmt.Sprintf("RegisterEnum(%q): already exists", name))
+	}
+	p.enums[name] = table
+}
+
+// EnumOf returns a name table of the given values using their
+// String method, e.g.
+//
+//	p.RegisterEnum("Color", EnumOf(Red, Green, Blue))
+func EnumOf[T interface {
+	integer
+	fmt.Stringer
+}](values ...T) map[string]int64 {
+	table := make(map[string]int64, len(values))
+	for _, v := range values {
+		table[v.String()] = int64(v)
+	}
+	return table
+}
+
+type integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// enumSetter returns a set func looking up values in the named
+// table.
+func (p *Picker) enumSetter(name string) (setfn, error) {
+	table, found := lookup(p, p.enums, name)
+	if !found {
+		return nil, fmt.Errorf("enum %s: unknown", name)
+	}
+	fn := func(field reflect.Value, v string) error {
+		n, found := table[v]
+		if !found {
+			return fmt.Errorf("enum %s: unknown value %q", name, v)
+		}
+		return p.setKind(field, strconv.FormatInt(n, 10))
+	}
+	return fn, nil
+}
+
+// setKind sets v using the setter of the field kind.
+func (p *Picker) setKind(field reflect.Value, v string) error {
+	fn, found := p.kindSetter(field.Kind())
+	if !found {
+		return fmt.Errorf("set %v: unsupported", field.Kind())
+	}
+	return fn(field, v)
+}
@@ -0,0 +1,55 @@
+package xr
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExamplePicker_RegisterEnum() {
+	p := NewPicker()
+	p.RegisterEnum("Color", EnumOf(Black, Red, Yellow))
+
+	var x struct {
+		Color Color `query:"color" enumOf:"Color"`
+	}
+	r := httptest.NewRequest("GET", "/?color=yellow", http.NoBody)
+	_ = p.Pick(&x, r)
+	fmt.Println(x.Color == Yellow)
+
+	r = httptest.NewRequest("GET", "/?color=neon", http.NoBody)
+	fmt.Println(p.Pick(&x, r))
+	// output:
+	// true
+	// pick Color from query[color]: enum Color: unknown value "neon"
+}
+
+func TestPicker_RegisterEnum_duplicate(t *testing.T) {
+	defer catchPanic(t)
+	p := NewPicker()
+	p.RegisterEnum("Color", nil)
+	p.RegisterEnum("Color", nil)
+}
+
+func TestPicker_enumSetter_unknown(t *testing.T) {
+	var x struct {
+		Color Color `query:"color" enumOf:"Jibberish"`
+	}
+	r := httptest.NewRequest("GET", "/?color=yellow", http.NoBody)
+	if err := Pick(&x, r); err == nil {
+		t.Error("expect error")
+	}
+}
+
+func TestPicker_enumSetter_outOfRange(t *testing.T) {
+	p := NewPicker()
+	p.RegisterEnum("Size", map[string]int64{"huge": 1000})
+	var x struct {
+		Size uint8 `query:"size" enumOf:"Size"`
+	}
+	r := httptest.NewRequest("GET", "/?size=huge", http.NoBody)
+	if err := p.Pick(&x, r); err == nil {
+		t.Error("expect error")
+	}
+}
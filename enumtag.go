@@ -0,0 +1,59 @@
+package xr
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// checkEnum returns an error if string or number field is not one of
+// the comma separated values, e.g. `enum:"asc,desc"`. Empty strings
+// are not checked.
+func checkEnum(field reflect.Value, arg string) error {
+	if !isScalar(field.Kind()) {
+		return fmt.Errorf("enum %v: unsupported", field.Kind())
+	}
+	if field.IsZero() && field.Kind() == reflect.String {
+		return nil
+	}
+	if !slices.ContainsFunc(strings.Split(arg, ","), equalTo(field)) {
+		return fmt.Errorf("enum %s: %q not allowed", arg, fmt.Sprint(field))
+	}
+	return nil
+}
+
+// equalTo returns a func returning true if string or number field
+// equals its argument.
+func equalTo(field reflect.Value) func(string) bool {
+	return func(v string) bool {
+		if field.Kind() == reflect.String {
+			return field.String() == v
+		}
+		n, err := strconv.ParseFloat(v, 64)
+		return err == nil && n == toFloat(field)
+	}
+}
+
+// isScalar returns true for string and real number kinds.
+func isScalar(k reflect.Kind) bool {
+	return k == reflect.String || isReal(k)
+}
+
+// isReal returns true for integer and float kinds.
+func isReal(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Float64
+}
+
+// toFloat returns number field as float64.
+func toFloat(field reflect.Value) float64 {
+	switch {
+	case field.CanInt():
+		return float64(field.Int())
+	case field.CanUint():
+		return float64(field.Uint())
+	default:
+		return field.Float()
+	}
+}
@@ -0,0 +1,43 @@
+package xr
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExamplePick_enum() {
+	var x struct {
+		Order string `query:"order" enum:"asc,desc"`
+		Size  int    `query:"size" enum:"10,25,50"`
+	}
+	r := httptest.NewRequest("GET", "/?order=desc&size=25", nil)
+	fmt.Println(Pick(&x, r), x.Order, x.Size)
+
+	r = httptest.NewRequest("GET", "/?order=up", nil)
+	fmt.Println(Pick(&x, r))
+	// output:
+	// <nil> desc 25
+	// pick Order from query[order]: enum asc,desc: "up" not allowed
+}
+
+func TestPick_enumTag(t *testing.T) {
+	var x struct {
+		Size  uint16  `query:"size" enum:"10,25"`
+		Ratio float64 `query:"ratio" enum:"0.5,1"`
+		On    bool    `query:"on" enum:"true"`
+	}
+	cases := map[string]bool{
+		"size=25":   true,
+		"size=26":   false,
+		"ratio=.50": true,
+		"ratio=2":   false,
+		"on=true":   false, // unsupported
+	}
+	for q, ok := range cases {
+		r := httptest.NewRequest("GET", "/?"+q, nil)
+		if err := Pick(&x, r); (err == nil) != ok {
+			t.Error(q, err)
+		}
+	}
+}
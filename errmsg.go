@@ -0,0 +1,23 @@
+package xr
+
+import "reflect"
+
+// withMessage sets the message of field tag errmsg on each
+// *PickError of err without one, e.g.
+//
+//	`query:"age" minimum:"18" errmsg:"age must be 18 or older"`
+//
+// For nested structs it applies to all failed fields without a
+// message of their own.
+func withMessage(field reflect.StructField, err error) error {
+	msg, found := field.Tag.Lookup("errmsg")
+	if !found {
+		return err
+	}
+	for _, e := range pickErrors(err) {
+		if e.Message == "" {
+			e.Message = msg
+		}
+	}
+	return err
+}
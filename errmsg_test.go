@@ -0,0 +1,42 @@
+package xr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExamplePick_errmsg() {
+	var x struct {
+		Age int `query:"age" minimum:"18" errmsg:"age must be 18 or older"`
+	}
+	r := httptest.NewRequest("GET", "/?age=12", http.NoBody)
+	err := Pick(&x, r)
+	fmt.Println(err)
+
+	var e *PickError
+	_ = errors.As(err, &e)
+	fmt.Println(e.Dest, e.Source)
+	// output:
+	// age must be 18 or older
+	// Age query[age]
+}
+
+func TestPick_errmsgNested(t *testing.T) {
+	type Range struct {
+		Min int `query:"min" minimum:"0"`
+		Max int `query:"max" maximum:"9" errmsg:"max too large"`
+	}
+	var x struct {
+		Range Range `errmsg:"invalid range"`
+	}
+	p := NewPicker()
+	p.SetAllErrors(true)
+	r := httptest.NewRequest("GET", "/?min=-1&max=10", http.NoBody)
+	err := p.Pick(&x, r)
+	if exp := "invalid range\nmax too large"; fmt.Sprint(err) != exp {
+		t.Errorf("got %v, exp %s", err, exp)
+	}
+}
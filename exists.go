@@ -0,0 +1,24 @@
+package xr
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// isExistsFlag returns true if field is tagged with
+// `exists:"true"`, meaning it's set to true if the source value is
+// present, regardless of the value, e.g. `?debug` or `?debug=no`.
+func isExistsFlag(field reflect.StructField) bool {
+	return isTagged(field.Tag, "exists")
+}
+
+// isTagged returns true if the value of tag name is true, e.g.
+// `required:"true"`.
+func isTagged(tag reflect.StructTag, name string) bool {
+	v, found := tag.Lookup(name)
+	if !found {
+		return false
+	}
+	on, _ := strconv.ParseBool(v)
+	return on
+}
@@ -0,0 +1,32 @@
+package xr
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExamplePick_exists() {
+	var x struct {
+		Debug   bool `query:"debug" exists:"true"`
+		Verbose bool `query:"verbose" exists:"true"`
+		Traced  bool `header:"traceparent" exists:"true"`
+	}
+	r := httptest.NewRequest("GET", "/?debug", http.NoBody)
+	r.Header.Set("traceparent", "00-0af7651916cd43dd8448eb211c80319c-01")
+	_ = Pick(&x, r)
+	fmt.Println(x.Debug, x.Verbose, x.Traced)
+	// output:
+	// true false true
+}
+
+func TestPick_exists_nonBool(t *testing.T) {
+	var x struct {
+		N int `query:"n" exists:"true"`
+	}
+	r := httptest.NewRequest("GET", "/?n", http.NoBody)
+	if err := Pick(&x, r); err == nil {
+		t.Error("expect error")
+	}
+}
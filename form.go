@@ -0,0 +1,114 @@
+package xr
+
+import (
+	"context"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+)
+
+// SetMultipartMemory sets the max number of bytes of a multipart
+// form kept in memory, the rest is stored in temporary files in
+// os.TempDir, see [http.Request.ParseMultipartForm]. Default is
+// [DefaultMultipartMemory]. Temporary files are removed once the
+// request context is done.
+func (p *Picker) SetMultipartMemory(maxMemory int64) {
+	p.multipartMemory = maxMemory
+}
+
+// DefaultMultipartMemory is the same as used by
+// http.Request.FormValue.
+const DefaultMultipartMemory = 32 << 20
+
+func (p *Picker) readForm(r *http.Request, name string) []string {
+	p.parseForm(r)
+	return r.Form[name]
+}
+
+// isFileField returns true if field is a *multipart.FileHeader or
+// []*multipart.FileHeader tagged form.
+func (p *Picker) isFileField(field reflect.StructField) bool {
+	_, found := p.tagName(field, "form")
+	t := field.Type
+	return found && (t == fileHeaderType || t == fileHeadersType)
+}
+
+var (
+	fileHeaderType  = reflect.TypeFor[*multipart.FileHeader]()
+	fileHeadersType = reflect.TypeFor[[]*multipart.FileHeader]()
+)
+
+// pickFiles sets field i of obj to the uploaded file, or files, of
+// a multipart form.
+func (p *Picker) pickFiles(obj reflect.Value, i int, r *http.Request) {
+	field := p.field(obj, i)
+	if !field.IsExported() {
+		panic(misuse("%v: private", field.Name))
+	}
+	p.parseForm(r)
+	name, _ := p.tagName(field, "form")
+	if r.MultipartForm == nil || len(r.MultipartForm.File[name]) == 0 {
+		return
+	}
+	files := r.MultipartForm.File[name]
+	if field.Type == fileHeaderType {
+		obj.Elem().Field(i).Set(reflect.ValueOf(files[0]))
+		return
+	}
+	obj.Elem().Field(i).Set(reflect.ValueOf(files))
+}
+
+// parseForm parses url encoded and multipart forms once. Errors are
+// ignored as with http.Request.FormValue. Temporary files of a
+// multipart form are removed when the request context is done.
+func (p *Picker) parseForm(r *http.Request) {
+	if r.MultipartForm != nil {
+		return
+	}
+	_ = r.ParseMultipartForm(p.multipartMemory)
+	removeOnDone(r)
+}
+
+// decodeMultipart parses a multipart/form-data body of r for picking
+// form tags, unless dst has part tags which are read as a stream.
+func (p *Picker) decodeMultipart(dst any, r *http.Request) error {
+	if len(p.partFields(dst)) > 0 {
+		return nil
+	}
+	err := r.ParseMultipartForm(p.multipartMemory)
+	removeOnDone(r)
+	return err
+}
+
+// decodeURLEncoded parses an application/x-www-form-urlencoded body
+// of r for picking form tags. Unlike http.Request.FormValue malformed
+// bodies result in an error.
+func decodeURLEncoded(r *http.Request) error {
+	return r.ParseForm()
+}
+
+// removeOnDone removes temporary files of a parsed multipart form
+// when the request context is done.
+func removeOnDone(r *http.Request) {
+	if form := r.MultipartForm; form != nil {
+		context.AfterFunc(r.Context(), func() { _ = form.RemoveAll() })
+	}
+}
+
+// isMultipart returns true if ct is multipart/form-data.
+func isMultipart(ct string) bool {
+	return mediaTypeOf(ct) == "multipart/form-data"
+}
+
+// isURLEncoded returns true if ct is
+// application/x-www-form-urlencoded.
+func isURLEncoded(ct string) bool {
+	return mediaTypeOf(ct) == "application/x-www-form-urlencoded"
+}
+
+// mediaTypeOf returns the media type of ct without parameters.
+func mediaTypeOf(ct string) string {
+	mediaType, _, _ := mime.ParseMediaType(ct)
+	return mediaType
+}
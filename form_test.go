@@ -1,11 +1,19 @@
 package xr
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io/fs"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
 	"strings"
+	"testing"
+	"time"
 )
 
 func ExamplePick_form() {
@@ -30,3 +38,182 @@ func ExamplePick_form() {
 	// output:
 	// name: John Doe
 }
+
+func TestPicker_SetMultipartMemory(t *testing.T) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	_ = w.WriteField("name", "John Doe")
+	fw, _ := w.CreateFormFile("file", "big.bin")
+	_, _ = fw.Write(make([]byte, 1024))
+	_ = w.Close()
+
+	r := httptest.NewRequest("POST", "/", &buf)
+	r.Header.Set("content-type", w.FormDataContentType())
+
+	p := NewPicker()
+	p.SetMultipartMemory(10)
+	var x struct {
+		Name string `form:"name"`
+	}
+	if err := p.Pick(&x, r); err != nil {
+		t.Fatal(err)
+	}
+	if x.Name != "John Doe" {
+		t.Error("got", x.Name)
+	}
+	defer r.MultipartForm.RemoveAll()
+	f, _ := r.MultipartForm.File["file"][0].Open()
+	defer f.Close()
+	if _, isFile := f.(*os.File); !isFile {
+		t.Errorf("expect file stored on disk, got %T", f)
+	}
+}
+
+func TestPicker_parseForm_cleanup(t *testing.T) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	fw, _ := w.CreateFormFile("file", "big.bin")
+	_, _ = fw.Write(make([]byte, 1024))
+	_ = w.WriteField("name", "John Doe")
+	_ = w.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := httptest.NewRequest("POST", "/", &buf).WithContext(ctx)
+	r.Header.Set("content-type", w.FormDataContentType())
+
+	p := NewPicker()
+	p.SetMultipartMemory(10)
+	var x struct {
+		Name string `form:"name"`
+	}
+	_ = p.Pick(&x, r)
+	f, _ := r.MultipartForm.File["file"][0].Open()
+	filename := f.(*os.File).Name()
+	f.Close()
+
+	cancel()
+	for i := 0; i < 100; i++ {
+		if _, err := os.Stat(filename); errors.Is(err, fs.ErrNotExist) {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Error("temporary file not removed", filename)
+}
+
+func ExamplePick_multipartForm() {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	_ = w.WriteField("name", "John Doe")
+	_ = w.WriteField("age", "42")
+	_ = w.Close()
+	r := httptest.NewRequest("POST", "/person", &buf)
+	r.Header.Set("content-type", w.FormDataContentType())
+
+	var x struct {
+		Name string `form:"name"`
+		Age  int    `form:"age"`
+	}
+	_ = Pick(&x, r)
+	fmt.Println(x.Name, x.Age)
+	// output:
+	// John Doe 42
+}
+
+func TestPick_multipartMalformed(t *testing.T) {
+	body := strings.NewReader("--x\r\nno end")
+	r := httptest.NewRequest("POST", "/", body)
+	r.Header.Set("content-type", "multipart/form-data; boundary=x")
+	var x struct {
+		Name string `form:"name"`
+	}
+	if err := Pick(&x, r); err == nil {
+		t.Error("expect error")
+	}
+}
+
+func TestPick_urlencodedMalformed(t *testing.T) {
+	r := httptest.NewRequest("POST", "/", strings.NewReader("name=%zz"))
+	r.Header.Set("content-type", "application/x-www-form-urlencoded")
+	var x struct {
+		Name string `form:"name"`
+	}
+	var e *PickError
+	if err := Pick(&x, r); !errors.As(err, &e) || e.Source != "body" {
+		t.Error("expect body error, got", err)
+	}
+}
+
+func TestPick_urlencodedStrict(t *testing.T) {
+	body := strings.NewReader("name=John&age=7")
+	r := httptest.NewRequest("POST", "/", body)
+	ct := "application/x-www-form-urlencoded; charset=utf-8"
+	r.Header.Set("content-type", ct)
+	p := NewPicker()
+	p.SetStrictMediaType(true)
+	var x struct {
+		Name string `form:"name"`
+		Age  int    `form:"age"`
+	}
+	if err := p.Pick(&x, r); err != nil {
+		t.Fatal(err)
+	}
+	if x.Name != "John" || x.Age != 7 {
+		t.Error("got", x)
+	}
+}
+
+func ExamplePick_fileHeader() {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	_ = w.WriteField("name", "John Doe")
+	fw, _ := w.CreateFormFile("avatar", "me.png")
+	_, _ = fw.Write([]byte("png"))
+	for _, name := range []string{"a.txt", "b.txt"} {
+		fw, _ := w.CreateFormFile("docs", name)
+		_, _ = fw.Write([]byte(name))
+	}
+	_ = w.Close()
+	r := httptest.NewRequest("POST", "/person", &buf)
+	r.Header.Set("content-type", w.FormDataContentType())
+
+	var x struct {
+		Name   string                  `form:"name"`
+		Avatar *multipart.FileHeader   `form:"avatar"`
+		Docs   []*multipart.FileHeader `form:"docs"`
+		Other  *multipart.FileHeader   `form:"other"`
+	}
+	_ = Pick(&x, r)
+	fmt.Println(x.Name, x.Avatar.Filename, len(x.Docs), x.Other)
+	// output:
+	// John Doe me.png 2 <nil>
+}
+
+func TestPick_fileHeaderPrivate(t *testing.T) {
+	defer catchPanic(t)
+	var x struct {
+		avatar *multipart.FileHeader `form:"avatar"`
+	}
+	_ = Pick(&x, httptest.NewRequest("GET", "/", nil))
+}
+
+func TestPick_fileHeaderNaming(t *testing.T) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	fw, _ := w.CreateFormFile("avatar_image", "me.png")
+	_, _ = fw.Write([]byte("png"))
+	_ = w.Close()
+	r := httptest.NewRequest("POST", "/person", &buf)
+	r.Header.Set("content-type", w.FormDataContentType())
+
+	type Person struct {
+		AvatarImage *multipart.FileHeader
+	}
+	p := NewPicker()
+	p.SetNaming(SnakeCase)
+	p.UseMapping(Person{}, NewMapping().Field("AvatarImage", `form:""`))
+	var x Person
+	if err := p.Pick(&x, r); err != nil || x.AvatarImage == nil {
+		t.Fatal(x, err)
+	}
+}
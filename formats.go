@@ -0,0 +1,86 @@
+package xr
+
+import (
+	"errors"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+// formats are built in formats of field tag format, used unless one
+// with the same name is registered, see Picker.RegisterFormat.
+var formats = map[string]func(string) error{
+	"email":     checkEmail,
+	"uuid":      matching(uuidPattern),
+	"ipv4":      checkIP(netip.Addr.Is4),
+	"ipv6":      checkIP(netip.Addr.Is6),
+	"hostname":  matching(hostnamePattern),
+	"uri":       checkURI,
+	"date":      layout(time.DateOnly),
+	"date-time": layout(time.RFC3339),
+}
+
+var (
+	uuidPattern = regexp.MustCompile(
+		`^[0-9a-fA-F]{8}(-[0-9a-fA-F]{4}){3}-[0-9a-fA-F]{12}$`,
+	)
+	hostnamePattern = regexp.MustCompile(
+		`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)*` +
+			`[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`,
+	)
+)
+
+func checkEmail(v string) error {
+	addr, err := mail.ParseAddress(v)
+	if err != nil {
+		return err
+	}
+	if addr.Address != v {
+		return errInvalid
+	}
+	return nil
+}
+
+func checkURI(v string) error {
+	u, err := url.Parse(v)
+	if err != nil {
+		return err
+	}
+	if !u.IsAbs() {
+		return errors.New("missing scheme")
+	}
+	return nil
+}
+
+func matching(re *regexp.Regexp) func(string) error {
+	return func(v string) error {
+		if !re.MatchString(v) {
+			return errInvalid
+		}
+		return nil
+	}
+}
+
+func checkIP(is func(netip.Addr) bool) func(string) error {
+	return func(v string) error {
+		addr, err := netip.ParseAddr(v)
+		if err != nil {
+			return err
+		}
+		if !is(addr) {
+			return errInvalid
+		}
+		return nil
+	}
+}
+
+func layout(layout string) func(string) error {
+	return func(v string) error {
+		_, err := time.Parse(layout, v)
+		return err
+	}
+}
+
+var errInvalid = errors.New("invalid")
@@ -0,0 +1,74 @@
+package xr
+
+import (
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExamplePick_formats() {
+	var x struct {
+		E string `query:"e" format:"email"`
+		D string `query:"d" format:"date"`
+	}
+	r := httptest.NewRequest("GET", "/?e=john@example.com&d=2024-09-01", nil)
+	fmt.Println(Pick(&x, r))
+
+	r = httptest.NewRequest("GET", "/?e=john", nil)
+	fmt.Println(Pick(&x, r))
+	// output:
+	// <nil>
+	// pick E from query[e]: format email: mail: missing '@' or angle-addr
+}
+
+func Test_formats(t *testing.T) {
+	cases := []struct {
+		format string
+		valid  []string
+		bad    []string
+	}{
+		{"email", []string{"a@b.se"}, []string{"A <a@b.se>", "a"}},
+		{"uuid",
+			[]string{"f47ac10b-58cc-4372-a567-0e02b2c3d479"},
+			[]string{"f47ac10b58cc4372a5670e02b2c3d479"},
+		},
+		{"ipv4", []string{"192.0.2.1"}, []string{"::1", "x"}},
+		{"ipv6", []string{"::1"}, []string{"192.0.2.1"}},
+		{"hostname", []string{"example.com", "a"}, []string{"-a.com"}},
+		{"uri", []string{"https://example.com"}, []string{"/path", ":"}},
+		{"date-time", []string{"2024-09-01T10:00:00Z"}, []string{"x"}},
+	}
+	for _, c := range cases {
+		checkFormats(t, c.format, c.valid, c.bad)
+	}
+}
+
+func checkFormats(t *testing.T, format string, valid, bad []string) {
+	t.Helper()
+	fn := formats[format]
+	for _, v := range valid {
+		if err := fn(v); err != nil {
+			t.Error(format, v, err)
+		}
+	}
+	for _, v := range bad {
+		if err := fn(v); err == nil {
+			t.Error(format, v, "expect error")
+		}
+	}
+}
+
+func TestPicker_RegisterFormat_builtin(t *testing.T) {
+	p := NewPicker()
+	p.RegisterFormat("email", func(string) error {
+		return errors.New("no email")
+	})
+	var x struct {
+		Email string `query:"email" format:"email"`
+	}
+	r := httptest.NewRequest("GET", "/?email=john@example.com", nil)
+	if err := p.Pick(&x, r); err == nil {
+		t.Error("expect registered format to be used")
+	}
+}
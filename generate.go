@@ -0,0 +1,46 @@
+package xr
+
+import (
+	"crypto/rand"
+	"fmt"
+	"reflect"
+)
+
+// SetGenerator sets the func used by field tag generate to create
+// missing values, e.g.
+//
+//	RequestId string `header:"X-Request-Id" generate:"uuid"`
+//
+// A uuid generator is predefined. Safe to call while picking.
+func (p *Picker) SetGenerator(name string, fn func() string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.generators[name] = fn
+}
+
+// pickGenerated sets field i of obj to a generated value if it has a
+// generate tag.
+func (p *Picker) pickGenerated(obj reflect.Value, i int, src source) error {
+	field := p.field(obj, i)
+	name, found := field.Tag.Lookup("generate")
+	if !found {
+		return nil
+	}
+	fn, found := lookup(p, p.generators, name)
+	if !found {
+		err := fmt.Errorf("generate %s: unknown", name)
+		return NewPickError(field.Name, src.String(), err)
+	}
+	return p.setValue(obj, i, fn(), src)
+}
+
+// newUUID returns a random, version 4, UUID.
+func newUUID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // variant 10
+	return fmt.Sprintf("%x-%x-%x-%x-%x",
+		b[0:4], b[4:6], b[6:8], b[8:10], b[10:],
+	)
+}
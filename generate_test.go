@@ -0,0 +1,52 @@
+package xr
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func ExamplePicker_SetGenerator() {
+	p := NewPicker()
+	p.SetGenerator("uuid", func() string {
+		return "00000000-0000-4000-8000-000000000000"
+	})
+	var x struct {
+		RequestId string `header:"X-Request-Id" generate:"uuid"`
+	}
+	r := httptest.NewRequest("GET", "/", http.NoBody)
+	_ = p.Pick(&x, r)
+	fmt.Println(x.RequestId)
+
+	r.Header.Set("X-Request-Id", "abc")
+	_ = p.Pick(&x, r)
+	fmt.Println(x.RequestId)
+	// output:
+	// 00000000-0000-4000-8000-000000000000
+	// abc
+}
+
+func Test_newUUID(t *testing.T) {
+	format := regexp.MustCompile(
+		`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`,
+	)
+	a, b := newUUID(), newUUID()
+	if !format.MatchString(a) {
+		t.Error("bad format", a)
+	}
+	if a == b {
+		t.Error("not random", a)
+	}
+}
+
+func TestPicker_pickGenerated_unknown(t *testing.T) {
+	var x struct {
+		Id string `header:"X-Request-Id" generate:"jibberish"`
+	}
+	r := httptest.NewRequest("GET", "/", http.NoBody)
+	if err := Pick(&x, r); err == nil {
+		t.Error("expect error")
+	}
+}
@@ -2,7 +2,6 @@ module github.com/gregoryv/xr
 
 go 1.22
 
-require (
-	github.com/gregoryv/gocyclo v0.1.1 // indirect
-	github.com/gregoryv/qual v0.4.3 // indirect
-)
+require github.com/gregoryv/qual v0.4.3
+
+require github.com/gregoryv/gocyclo v0.1.1 // indirect
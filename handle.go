@@ -0,0 +1,53 @@
+package xr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Handle returns a http.Handler picking In from requests, calling fn
+// and responding with Out using PickerDefault, see HandleWith.
+func Handle[In, Out any](fn func(context.Context, In) (Out, error),
+) http.Handler {
+	return HandleWith(PickerDefault, fn)
+}
+
+// HandleWith returns a http.Handler picking In, a struct, from
+// requests using p and calling fn with the request context. Out is
+// written with status 200 OK, see Picker.Respond. Errors, from
+// picking or returned by fn, are written as by Picker.Handler.
+func HandleWith[In, Out any](p *Picker,
+	fn func(context.Context, In) (Out, error),
+) http.Handler {
+	return p.Handler(func(w http.ResponseWriter, r *http.Request) error {
+		var in In
+		if err := p.Pick(&in, r); err != nil {
+			return err
+		}
+		out, err := fn(r.Context(), in)
+		if err != nil {
+			return err
+		}
+		return p.Respond(w, r, http.StatusOK, out)
+	})
+}
+
+// Route registers Handle(fn) on mux for pattern, see RouteWith.
+func Route[In, Out any](mux *http.ServeMux, pattern string,
+	fn func(context.Context, In) (Out, error),
+) {
+	RouteWith(PickerDefault, mux, pattern, fn)
+}
+
+// RouteWith registers HandleWith(p, fn) on mux for pattern. Panics if
+// the path tags of In and the wildcards of pattern differ, see
+// Picker.CheckPattern.
+func RouteWith[In, Out any](p *Picker, mux *http.ServeMux, pattern string,
+	fn func(context.Context, In) (Out, error),
+) {
+	if err := p.CheckPattern(pattern, new(In)); err != nil {
+		panic(fmt.Sprintf("Route(%q): %v", pattern, err))
+	}
+	mux.Handle(pattern, HandleWith(p, fn))
+}
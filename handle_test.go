@@ -0,0 +1,68 @@
+package xr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExampleHandle() {
+	type In struct {
+		Name string `query:"name" required:"true"`
+	}
+	type Out struct {
+		Greeting string `json:"greeting"`
+	}
+	h := Handle(func(ctx context.Context, in In) (Out, error) {
+		return Out{Greeting: "Hello " + in.Name}, nil
+	})
+	for _, target := range []string{"/?name=John", "/"} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", target, nil))
+		fmt.Print(w.Code, " ", w.Body.String())
+	}
+	// output:
+	// 200 {"greeting":"Hello John"}
+	// 400 {"error":"pick Name from query[name]: required"}
+}
+
+func TestHandleWith_error(t *testing.T) {
+	p := NewPicker()
+	p.RegisterEncoder("application/json", JSONEncoder)
+	h := HandleWith(p, func(context.Context, struct{}) (int, error) {
+		return 0, errors.New("oops")
+	})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Error(w.Code)
+	}
+}
+
+func ExampleRoute() {
+	type In struct {
+		Id string `path:"id"`
+	}
+	mux := http.NewServeMux()
+	Route(mux, "GET /items/{id}", func(_ context.Context, in In) (In, error) {
+		return in, nil
+	})
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/items/7", nil))
+	fmt.Print(w.Body.String())
+	// output:
+	// {"Id":"7"}
+}
+
+func TestRoute_mismatch(t *testing.T) {
+	defer catchPanic(t)
+	type In struct {
+		Id string `path:"id"`
+	}
+	Route(http.NewServeMux(), "GET /items/{key}",
+		func(context.Context, In) (int, error) { return 0, nil },
+	)
+}
@@ -0,0 +1,82 @@
+package xr
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+)
+
+// HandlerFunc handles a request, returning errors instead of writing
+// them, see Picker.Handler.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// ErrorWriter writes err as the response of r.
+type ErrorWriter func(w http.ResponseWriter, r *http.Request, err error)
+
+// Handler returns a http.Handler calling fn. Returned errors are
+// logged, if configured with SetErrorLog, and written using the error
+// writer of p, see SetErrorWriter.
+func (p *Picker) Handler(fn HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := fn(w, r)
+		if err == nil {
+			return
+		}
+		if p.errorLog != nil {
+			p.errorLog.Printf("%s %s: %v", r.Method, r.URL.Path, err)
+		}
+		p.Translate(r, err)
+		p.errorWriter(w, r, err)
+	})
+}
+
+// SetErrorWriter sets the writer of errors returned to handlers, see
+// Handler. Defaults to WriteJSONError.
+func (p *Picker) SetErrorWriter(fn ErrorWriter) {
+	p.errorWriter = fn
+}
+
+// SetErrorLog sets the logger of errors returned to handlers. Errors
+// are not logged by default.
+func (p *Picker) SetErrorLog(l *log.Logger) {
+	p.errorLog = l
+}
+
+// WriteJSONError writes err as {"error": "..."} with the status of
+// err, see StatusOf. Messages of internal errors are not exposed.
+func WriteJSONError(w http.ResponseWriter, r *http.Request, err error) {
+	status := StatusOf(err)
+	msg := err.Error()
+	if status >= 500 {
+		msg = http.StatusText(status)
+	}
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
+
+// StatusOf returns the http status code of err. Errors from picking
+// are client errors, all others result in 500.
+func StatusOf(err error) int {
+	for _, e := range errorStatus {
+		if errors.Is(err, e.err) {
+			return e.status
+		}
+	}
+	var pickErr *PickError
+	if errors.As(err, &pickErr) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// errorStatus maps errors to http status codes, checked in order.
+var errorStatus = []struct {
+	err    error
+	status int
+}{
+	{ErrTooLarge, http.StatusRequestEntityTooLarge},
+	{ErrUnsupportedMediaType, http.StatusUnsupportedMediaType},
+	{ErrNotAcceptable, http.StatusNotAcceptable},
+}
@@ -0,0 +1,64 @@
+package xr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func ExampleHandler() {
+	h := Handler(func(w http.ResponseWriter, r *http.Request) error {
+		var x struct {
+			N int `query:"n"`
+		}
+		if err := Pick(&x, r); err != nil {
+			return err
+		}
+		fmt.Fprint(w, x.N)
+		return nil
+	})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/?n=x", nil))
+	fmt.Println(w.Code)
+	fmt.Print(w.Body.String())
+	// output:
+	// 400
+	// {"error":"pick N from query[n]: ParseInt: parsing \"x\": invalid syntax"}
+}
+
+func TestPicker_Handler_internal(t *testing.T) {
+	p := NewPicker()
+	var buf bytes.Buffer
+	p.SetErrorLog(log.New(&buf, "", 0))
+	h := p.Handler(func(w http.ResponseWriter, r *http.Request) error {
+		return errors.New("secret")
+	})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/x", nil))
+	if w.Code != 500 || strings.Contains(w.Body.String(), "secret") {
+		t.Error(w.Code, w.Body.String())
+	}
+	if got := buf.String(); got != "GET /x: secret\n" {
+		t.Errorf("log %q", got)
+	}
+}
+
+func TestPicker_SetErrorWriter(t *testing.T) {
+	p := NewPicker()
+	p.SetErrorWriter(func(w http.ResponseWriter, _ *http.Request, err error) {
+		http.Error(w, err.Error(), StatusOf(err))
+	})
+	h := p.Handler(func(w http.ResponseWriter, r *http.Request) error {
+		return fmt.Errorf("%w", ErrTooLarge)
+	})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Error(w.Code)
+	}
+}
@@ -0,0 +1,66 @@
+package xr
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// SetRuneLength controls if field tags minLength and maxLength count
+// runes instead of bytes. The unit can also be set per field, e.g.
+// `maxLength:"20,runes"` or `maxLength:"20,bytes"`.
+func (p *Picker) SetRuneLength(v bool) {
+	p.runeLength = v
+}
+
+func (p *Picker) checkMinLength(field reflect.Value, arg string) error {
+	limit, n, err := p.length("minLength", field, arg)
+	if err == nil && n < limit {
+		err = fmt.Errorf("minLength %v: too short", limit)
+	}
+	return err
+}
+
+func (p *Picker) checkMaxLength(field reflect.Value, arg string) error {
+	limit, n, err := p.length("maxLength", field, arg)
+	if err == nil && n > limit {
+		err = fmt.Errorf("maxLength %v: too long", limit)
+	}
+	return err
+}
+
+// length returns the limit of arg, e.g. "20,runes", and the length
+// of string field in the unit of arg.
+func (p *Picker) length(tag string, field reflect.Value, arg string) (
+	limit, n int, err error,
+) {
+	if field.Kind() != reflect.String {
+		return 0, 0, fmt.Errorf("%s %v: unsupported", tag, field.Kind())
+	}
+	num, unit, _ := strings.Cut(arg, ",")
+	limit, err = strconv.Atoi(num)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s: %w", tag, err)
+	}
+	n, err = p.lengthOf(field.String(), unit)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s: %w", tag, err)
+	}
+	return limit, n, nil
+}
+
+// lengthOf returns the length of s in runes or bytes.
+func (p *Picker) lengthOf(s, unit string) (int, error) {
+	switch {
+	case unit == "runes", unit == "" && p.runeLength:
+		return utf8.RuneCountInString(s), nil
+
+	case unit == "bytes", unit == "":
+		return len(s), nil
+
+	default:
+		return 0, fmt.Errorf("unit %s: unknown", unit)
+	}
+}
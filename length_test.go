@@ -0,0 +1,81 @@
+package xr
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func ExamplePicker_SetRuneLength() {
+	p := NewPicker()
+	p.SetRuneLength(true)
+	var x struct {
+		Name string `query:"name" maxLength:"4"`
+	}
+	r := httptest.NewRequest("GET", "/?name=Åsa", nil)
+	fmt.Println(p.Pick(&x, r), x.Name)
+	// output:
+	// <nil> Åsa
+}
+
+func TestPicker_maxLength(t *testing.T) {
+	cases := []struct {
+		tag  string
+		name string
+		ok   bool
+	}{
+		{`query:"n" maxLength:"3"`, "Åsa", false}, // 4 bytes
+		{`query:"n" maxLength:"3,runes"`, "Åsa", true},
+		{`query:"n" maxLength:"3,bytes"`, "abc", true},
+		{`query:"n" maxLength:"3,words"`, "abc", false},
+		{`query:"n" maxLength:"x"`, "abc", false},
+	}
+	for _, c := range cases {
+		err := pickTagged(NewPicker(), c.tag, c.name)
+		if (err == nil) != c.ok {
+			t.Error(c.tag, c.name, err)
+		}
+	}
+}
+
+func TestPicker_minLength(t *testing.T) {
+	cases := []struct {
+		tag  string
+		name string
+		ok   bool
+	}{
+		{`query:"n" minLength:"4"`, "Åsa", true},
+		{`query:"n" minLength:"4,runes"`, "Åsa", false},
+		{`query:"n" minLength:"4"`, "abc", false},
+	}
+	for _, c := range cases {
+		err := pickTagged(NewPicker(), c.tag, c.name)
+		if (err == nil) != c.ok {
+			t.Error(c.tag, c.name, err)
+		}
+	}
+}
+
+func TestPicker_minLength_unsupported(t *testing.T) {
+	var x struct {
+		N int `query:"n" minLength:"1"`
+	}
+	r := httptest.NewRequest("GET", "/?n=1", nil)
+	if err := Pick(&x, r); err == nil {
+		t.Error("expect error")
+	}
+}
+
+// pickTagged picks value of query parameter n into a string field
+// with the given tag.
+func pickTagged(p *Picker, tag, value string) error {
+	t := reflect.StructOf([]reflect.StructField{{
+		Name: "N",
+		Type: reflect.TypeFor[string](),
+		Tag:  reflect.StructTag(tag),
+	}})
+	r := httptest.NewRequest("GET", "/?n="+url.QueryEscape(value), nil)
+	return p.Pick(reflect.New(t).Interface(), r)
+}
@@ -0,0 +1,29 @@
+package xr
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// SetMaxBodyBytes limits the size of bodies read by Pick. Larger
+// bodies result in ErrTooLarge. Default is 0, no limit.
+func (p *Picker) SetMaxBodyBytes(n int64) {
+	p.maxBodyBytes = n
+}
+
+// limitBody wraps the body of r with the max body size, if any.
+// Returns ErrTooLarge if the content length is already known to be
+// too large.
+func (p *Picker) limitBody(r *http.Request) error {
+	if p.maxBodyBytes <= 0 || r.Body == nil {
+		return nil
+	}
+	if r.ContentLength > p.maxBodyBytes {
+		return fmt.Errorf("%w: max %d bytes", ErrTooLarge, p.maxBodyBytes)
+	}
+	r.Body = readCloser{
+		Reader: &maxReader{r: r.Body, left: p.maxBodyBytes},
+		Closer: r.Body,
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package xr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func ExamplePicker_SetMaxBodyBytes() {
+	p := NewPicker()
+	p.Register("application/json", JSONDecoder)
+	p.SetMaxBodyBytes(8)
+	var c Car
+	body := strings.NewReader(`{"sold":true}`)
+	r := httptest.NewRequest("POST", "/", body)
+	r.Header.Set("content-type", "application/json")
+	err := p.Pick(&c, r)
+	fmt.Println(errors.Is(err, ErrTooLarge), StatusOf(err))
+	// output:
+	// true 413
+}
+
+func TestPicker_SetMaxBodyBytes_unknownLength(t *testing.T) {
+	p := NewPicker()
+	p.Register("application/json", JSONDecoder)
+	p.SetMaxBodyBytes(8)
+	var c Car
+	body := io.MultiReader(strings.NewReader(`{"sold":true}`))
+	r := httptest.NewRequest("POST", "/", body)
+	r.Header.Set("content-type", "application/json")
+	if err := p.Pick(&c, r); !errors.Is(err, ErrTooLarge) {
+		t.Error("expect ErrTooLarge, got", err)
+	}
+}
+
+func TestPicker_SetMaxBodyBytes_within(t *testing.T) {
+	p := NewPicker()
+	p.Register("application/json", JSONDecoder)
+	p.SetMaxBodyBytes(13)
+	var c Car
+	body := strings.NewReader(`{"sold":true}`)
+	r := httptest.NewRequest("POST", "/", body)
+	r.Header.Set("content-type", "application/json")
+	if err := p.Pick(&c, r); err != nil || !c.Sold {
+		t.Error(c, err)
+	}
+}
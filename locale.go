@@ -0,0 +1,94 @@
+package xr
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// SetLocale sets the locale used when parsing numbers, e.g. "sv"
+// accepts "1 234,5". Use field tag locale to set it for individual
+// fields. Predefined locales are en, de, fr and sv.
+func (p *Picker) SetLocale(name string) {
+	p.locale = name
+}
+
+// RegisterLocale adds or replaces a locale with the given decimal
+// separator and thousands separators. Safe to call while picking.
+func (p *Picker) RegisterLocale(name string, decimal rune, thousands string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.locales[name] = numberFormat(decimal, thousands)
+}
+
+// numberFormat returns a locale normalizing numbers to the format
+// expected by strconv.
+func numberFormat(decimal rune, thousands string) *numberLocale {
+	var oldnew, seps []string
+	for _, r := range thousands {
+		oldnew = append(oldnew, string(r), "")
+		seps = append(seps, regexp.QuoteMeta(string(r)))
+	}
+	oldnew = append(oldnew, string(decimal), ".")
+	grouped := fmt.Sprintf(`^[+-]?\d{1,3}(?:(?:%s)\d{3})+(?:%s\d*)?$`,
+		strings.Join(seps, "|"), regexp.QuoteMeta(string(decimal)),
+	)
+	return &numberLocale{
+		thousands: thousands,
+		grouped:   regexp.MustCompile(grouped),
+		replacer:  strings.NewReplacer(oldnew...),
+	}
+}
+
+// numberLocale formats numbers of a locale.
+type numberLocale struct {
+	thousands string
+	grouped   *regexp.Regexp
+	replacer  *strings.Replacer
+}
+
+// normalize v, thousands separators must group exactly three digits
+// of the integer part, e.g. de "1.5" is an error and not 15.
+func (f *numberLocale) normalize(v string) (string, error) {
+	if strings.ContainsAny(v, f.thousands) && !f.grouped.MatchString(v) {
+		return "", fmt.Errorf("%q: invalid digit grouping", v)
+	}
+	return f.replacer.Replace(v), nil
+}
+
+// localize normalizes numbers according to the locale of the field
+// or picker.
+func (p *Picker) localize(field reflect.StructField, v string) (
+	string, error,
+) {
+	name, found := field.Tag.Lookup("locale")
+	if !found {
+		name = p.locale
+	}
+	if name == "" || !isNumber(field.Type.Kind()) {
+		return v, nil
+	}
+	format, err := p.numberFormat(name)
+	if err != nil {
+		return "", err
+	}
+	return format.normalize(v)
+}
+
+// numberFormat returns the format of the named locale, falling back
+// on the language, e.g. sv-SE uses sv.
+func (p *Picker) numberFormat(name string) (*numberLocale, error) {
+	if format, found := lookup(p, p.locales, name); found {
+		return format, nil
+	}
+	lang, _, _ := strings.Cut(name, "-")
+	if format, found := lookup(p, p.locales, lang); found {
+		return format, nil
+	}
+	return nil, fmt.Errorf("locale %s: unknown", name)
+}
+
+func isNumber(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Complex128
+}
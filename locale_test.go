@@ -0,0 +1,99 @@
+package xr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func ExamplePicker_SetLocale() {
+	p := NewPicker()
+	p.SetLocale("sv-SE")
+
+	var x struct {
+		Price  float64 `query:"price"`
+		Amount int     `query:"amount" locale:"en"`
+	}
+	q := url.Values{
+		"price":  []string{"1 234,50"},
+		"amount": []string{"1,000"},
+	}
+	r := httptest.NewRequest("GET", "/?"+q.Encode(), http.NoBody)
+	_ = p.Pick(&x, r)
+	fmt.Println(x.Price, x.Amount)
+	// output:
+	// 1234.5 1000
+}
+
+func TestPicker_localize_language(t *testing.T) {
+	p := NewPicker()
+	var x struct {
+		N float32 `query:"n" locale:"de-CH"`
+		S string  `query:"s" locale:"de-CH"`
+	}
+	r := httptest.NewRequest("GET", "/?n=1.000,5&s=1.000,5", http.NoBody)
+	if err := p.Pick(&x, r); err != nil {
+		t.Fatal(err)
+	}
+	if x.N != 1000.5 || x.S != "1.000,5" {
+		t.Error("got", x)
+	}
+
+}
+
+func TestPicker_RegisterLocale(t *testing.T) {
+	p := NewPicker()
+	p.RegisterLocale("ch", '.', "'")
+	var y struct {
+		N float32 `query:"n" locale:"ch"`
+	}
+	r := httptest.NewRequest("GET", "/?n=1'000.5", http.NoBody)
+	if err := p.Pick(&y, r); err != nil || y.N != 1000.5 {
+		t.Error(y, err)
+	}
+}
+
+func TestPicker_localize_unknown(t *testing.T) {
+	var x struct {
+		N int `query:"n" locale:"xx"`
+	}
+	r := httptest.NewRequest("GET", "/?n=1", http.NoBody)
+	if err := Pick(&x, r); err == nil {
+		t.Error("expect error")
+	}
+}
+
+func TestPicker_localize_grouping(t *testing.T) {
+	p := NewPicker()
+	cases := []struct {
+		locale, v string
+		ok        bool
+	}{
+		{"de", "1.5", false},
+		{"en", "1,5", false},
+		{"en", "1,0000", false},
+		{"en", "1,,000", false},
+		{"en", ",100", false},
+		{"en", "1234,567", false},
+		{"en", "1,000.5,5", false},
+		{"sv", "1 234,5", true},
+		{"en", "-1,234,567.25", true},
+		{"en", "1234.5", true},
+	}
+	for _, c := range cases {
+		p.SetLocale(c.locale)
+		var x struct {
+			N float64 `query:"n"`
+		}
+		q := url.Values{"n": []string{c.v}}
+		r := httptest.NewRequest("GET", "/?"+q.Encode(), http.NoBody)
+		err := p.Pick(&x, r)
+		var e *PickError
+		if c.ok != (err == nil) || !c.ok && !errors.As(err, &e) {
+			t.Errorf("%s %q: %v", c.locale, c.v, err)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package xr
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// NewMapping returns an empty mapping, see [Picker.UseMapping].
+func NewMapping() *Mapping {
+	return &Mapping{
+		tags: make(map[string]reflect.StructTag),
+	}
+}
+
+// Mapping defines field tags for types that cannot be annotated,
+// e.g. generated code or third party structs.
+type Mapping struct {
+	tags map[string]reflect.StructTag
+}
+
+// Field sets the tags of the named field, replacing any declared
+// in the struct, e.g.
+//
+//	NewMapping().Field("Id", `path:"id"`).Field("Name", `query:"name"`)
+func (m *Mapping) Field(name, tag string) *Mapping {
+	m.tags[name] = reflect.StructTag(tag)
+	return m
+}
+
+// UseMapping makes the picker use the field tags of m, instead of
+// the declared ones, when picking into values of the same type as
+// v. Safe to call while picking. Panics if v is not a struct or
+// pointer to a struct, or if m refers to missing fields.
+func (p *Picker) UseMapping(v any, m *Mapping) {
+	t := reflect.TypeOf(v)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("UseMapping(%v): not a struct", t))
+	}
+	for name := range m.tags {
+		if _, found := t.FieldByName(name); !found {
+			panic(fmt.Sprintf("UseMapping(%v): no field %s", t, name))
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.mappings[t] = m.tags
+	p.plans.Delete(t)
+	p.trailerTypes.Range(func(t, _ any) bool {
+		p.trailerTypes.Delete(t)
+		return true
+	})
+}
+
+// field returns field i of obj with tags from a mapping if any.
+func (p *Picker) field(obj reflect.Value, i int) reflect.StructField {
+	return p.planOf(obj.Elem().Type())[i].StructField
+}
+
+// fieldPlan describes how a struct field is picked, see planOf.
+type fieldPlan struct {
+	// field with tags from a mapping if any
+	reflect.StructField
+
+	// source of the first tag in readerKinds, empty if none
+	source string
+
+	// capture is the source of a capture tag, e.g. `query:"sort*"`
+	capture string
+
+	// files and deepObject are set for fields picked by pickFiles
+	// and pickDeepObject
+	files, deepObject bool
+}
+
+// planOf returns the plans of the fields of struct t, cached by
+// type. Plans are made holding the read lock of p.mu so they're not
+// cached after being removed by UseMapping.
+func (p *Picker) planOf(t reflect.Type) []fieldPlan {
+	if cached, found := p.plans.Load(t); found {
+		return cached.([]fieldPlan)
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	plan := make([]fieldPlan, t.NumField())
+	for i := range plan {
+		field := t.Field(i)
+		if tag, found := p.mappings[t][field.Name]; found {
+			field.Tag = tag
+		}
+		plan[i] = p.planField(field)
+	}
+	p.plans.Store(t, plan)
+	return plan
+}
+
+// planField returns the plan of field.
+func (p *Picker) planField(field reflect.StructField) fieldPlan {
+	capture, _, _ := captureTag(field.Tag)
+	return fieldPlan{
+		StructField: field,
+		source:      sourceKind(field),
+		capture:     capture,
+		files:       p.isFileField(field),
+		deepObject:  p.isDeepObject(field),
+	}
+}
+
+// sourceKind returns the first kind in readerKinds field is tagged
+// with, empty if none.
+func sourceKind(field reflect.StructField) string {
+	for _, kind := range readerKinds {
+		if _, found := field.Tag.Lookup(kind); found {
+			return kind
+		}
+	}
+	return ""
+}
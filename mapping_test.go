@@ -0,0 +1,42 @@
+package xr
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExamplePicker_UseMapping() {
+	// e.g. a generated type
+	type Order struct {
+		Id    string
+		Count int
+		Note  string `query:"note"`
+	}
+	p := NewPicker()
+	p.UseMapping(Order{}, NewMapping().
+		Field("Id", `path:"id"`).
+		Field("Count", `query:"count"`),
+	)
+
+	var x Order
+	r := httptest.NewRequest("GET", "/?count=3&note=hi", http.NoBody)
+	r.SetPathValue("id", "A1")
+	_ = p.Pick(&x, r)
+	fmt.Printf("%+v", x)
+	// output:
+	// {Id:A1 Count:3 Note:hi}
+}
+
+func TestPicker_UseMapping_panics(t *testing.T) {
+	p := NewPicker()
+	t.Run("not struct", func(t *testing.T) {
+		defer catchPanic(t)
+		p.UseMapping(1, NewMapping())
+	})
+	t.Run("missing field", func(t *testing.T) {
+		defer catchPanic(t)
+		p.UseMapping(&Car{}, NewMapping().Field("Model", `query:"m"`))
+	})
+}
@@ -0,0 +1,90 @@
+package xr
+
+import (
+	"net/http"
+	"reflect"
+)
+
+// SetZeroFirst controls if the destination is zeroed before
+// picking. By default existing values are kept for absent sources,
+// which is useful when picking into a struct with defaults.
+func (p *Picker) SetZeroFirst(v bool) {
+	p.zeroFirst = v
+}
+
+// SetNoOverwrite controls if fields with non-zero values are kept
+// even if a value is given in the request. Kept values are neither
+// set nor checked, nested structs and pointed to structs are kept
+// field by field. Has no effect in zero first mode.
+func (p *Picker) SetNoOverwrite(v bool) {
+	p.noOverwrite = v
+}
+
+// prepare dst according to zero first mode.
+func (p *Picker) prepare(dst any) {
+	if p.zeroFirst {
+		reflect.ValueOf(dst).Elem().SetZero()
+	}
+}
+
+// keep returns true if field i of obj is set from a source, e.g. a
+// query parameter, but has a value kept in no overwrite mode.
+func (p *Picker) keep(obj reflect.Value, i int) bool {
+	if !p.noOverwrite || p.zeroFirst {
+		return false
+	}
+	field := p.field(obj, i)
+	return hasSource(field) && !p.isNested(field) &&
+		!obj.Elem().Field(i).IsZero()
+}
+
+// hasSource returns true if field is set from the request other than
+// from the body.
+func hasSource(field reflect.StructField) bool {
+	return hasReaderTag(field) || isContextField(field)
+}
+
+// decodeKept decodes the body of r into dst. In no overwrite mode
+// the body is decoded into a new value and only zero fields of dst
+// are set from it.
+func (p *Picker) decodeKept(dst any, r *http.Request) error {
+	if !p.noOverwrite || p.zeroFirst {
+		return p.decode(dst, r)
+	}
+	tmp := reflect.New(reflect.TypeOf(dst).Elem())
+	err := p.decode(tmp.Interface(), r)
+	mergeZero(reflect.ValueOf(dst).Elem(), tmp.Elem())
+	return err
+}
+
+// mergeZero sets each zero field of struct obj to its value in src.
+// Non-zero structs, also pointed to, are merged field by field.
+func mergeZero(obj, src reflect.Value) {
+	for i := 0; i < obj.NumField(); i++ {
+		mergeField(obj.Field(i), src.Field(i))
+	}
+}
+
+func mergeField(dst, src reflect.Value) {
+	switch {
+	case isUnset(dst):
+		dst.Set(src)
+
+	case dst.Kind() == reflect.Struct:
+		mergeZero(dst, src)
+
+	case isStructPointer(dst) && !src.IsNil():
+		mergeZero(dst.Elem(), src.Elem())
+	}
+}
+
+// isUnset returns true if v is a settable zero value.
+func isUnset(v reflect.Value) bool {
+	return v.CanSet() && v.IsZero()
+}
+
+// isStructPointer returns true if v is a non-nil pointer to a struct.
+func isStructPointer(v reflect.Value) bool {
+	return v.Kind() == reflect.Pointer && !v.IsNil() &&
+		v.Elem().Kind() == reflect.Struct
+}
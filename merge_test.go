@@ -0,0 +1,92 @@
+package xr
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func ExamplePicker_SetZeroFirst() {
+	p := NewPicker()
+	p.SetZeroFirst(true)
+
+	x := struct {
+		Page int `query:"page"`
+		Size int `query:"size"`
+	}{
+		Size: 10,
+	}
+	r := httptest.NewRequest("GET", "/?page=2", http.NoBody)
+	_ = p.Pick(&x, r)
+	fmt.Printf("%+v", x)
+	// output:
+	// {Page:2 Size:0}
+}
+
+func ExamplePicker_SetNoOverwrite() {
+	p := NewPicker()
+	p.Register("application/json", JSONDecoder)
+	p.SetNoOverwrite(true)
+
+	x := struct {
+		Name  string `json:"name"`
+		Owner string `header:"owner"`
+		Color string `query:"color"`
+	}{
+		Name:  "default",
+		Owner: "admin",
+	}
+	body := strings.NewReader(`{"name":"John"}`)
+	r := httptest.NewRequest("POST", "/?color=red", body)
+	r.Header.Set("content-type", "application/json")
+	r.Header.Set("owner", "guest")
+	_ = p.Pick(&x, r)
+	fmt.Printf("%+v", x)
+	// output:
+	// {Name:default Owner:admin Color:red}
+}
+
+func TestPicker_SetNoOverwrite_checked(t *testing.T) {
+	p := NewPicker()
+	p.SetNoOverwrite(true)
+	x := struct {
+		Age int `query:"age" maximum:"10"`
+	}{
+		Age: 3,
+	}
+	r := httptest.NewRequest("GET", "/?age=50", http.NoBody)
+	if err := p.Pick(&x, r); err != nil || x.Age != 3 {
+		t.Error(x.Age, err)
+	}
+}
+
+func TestPicker_SetNoOverwrite_nested(t *testing.T) {
+	type Address struct {
+		Street string `query:"street"`
+		City   string `json:"city"`
+		Zip    string `json:"zip"`
+	}
+	p := NewPicker()
+	p.Register("application/json", JSONDecoder)
+	p.SetNoOverwrite(true)
+	x := struct {
+		Home Address `query:"home."`
+		Work *Address
+	}{
+		Home: Address{Street: "Elm"},
+		Work: &Address{City: "Oslo"},
+	}
+	body := strings.NewReader(`{"Work":{"city":"Rome","zip":"00100"}}`)
+	r := httptest.NewRequest("POST", "/?home.street=Oak", body)
+	r.Header.Set("content-type", "application/json")
+	if err := p.Pick(&x, r); err != nil {
+		t.Fatal(err)
+	}
+	got := fmt.Sprintf("%+v %+v", x.Home, *x.Work)
+	exp := "{Street:Elm City: Zip:} {Street: City:Oslo Zip:00100}"
+	if got != exp {
+		t.Errorf("\ngot %s\nexp %s", got, exp)
+	}
+}
@@ -0,0 +1,163 @@
+package xr
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// readMeta returns request metadata by name, e.g. `request:"hostname"`.
+// Panics on unknown names.
+func (p *Picker) readMeta(r *http.Request, name string) []string {
+	fn, found := metaReaders[name]
+	if !found {
+		panic(misuse("request[%s]: unknown", name))
+	}
+	return nonEmpty(fn(p, r))
+}
+
+// metaReaders map names of the request field tag to funcs reading
+// the value.
+var metaReaders = map[string]func(*Picker, *http.Request) string{
+	"hostname": func(_ *Picker, r *http.Request) string {
+		host, _ := splitHost(requestHost(r))
+		return host
+	},
+	"port": func(_ *Picker, r *http.Request) string {
+		_, port := splitHost(requestHost(r))
+		return port
+	},
+	"scheme":   (*Picker).scheme,
+	"remoteip": (*Picker).remoteIP,
+	"method": func(_ *Picker, r *http.Request) string {
+		return r.Method
+	},
+	"host": func(_ *Picker, r *http.Request) string {
+		return requestHost(r)
+	},
+	"uri": func(_ *Picker, r *http.Request) string {
+		return r.URL.RequestURI()
+	},
+}
+
+// requestHost returns r.Host or the URL host for outgoing requests.
+func requestHost(r *http.Request) string {
+	if r.Host != "" {
+		return r.Host
+	}
+	return r.URL.Host
+}
+
+// splitHost splits host into hostname and port. The port is empty
+// if not given. Brackets of IPv6 literals are removed.
+func splitHost(host string) (string, string) {
+	hostname, port, err := net.SplitHostPort(host)
+	if err != nil {
+		return strings.Trim(host, "[]"), ""
+	}
+	return hostname, port
+}
+
+// scheme returns http or https. Headers Forwarded and
+// X-Forwarded-Proto are only honored from trusted proxies.
+func (p *Picker) scheme(r *http.Request) string {
+	if !p.fromTrustedProxy(r) {
+		return connScheme(r)
+	}
+	if v := p.forwardedProto(r); v != "" {
+		return v
+	}
+	return connScheme(r)
+}
+
+// connScheme returns the scheme of the connection of r.
+func connScheme(r *http.Request) string {
+	if r.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
+
+// forwardedProto returns the proto appended by the trusted proxy
+// nearest the client, from the Forwarded header or else
+// X-Forwarded-Proto.
+func (p *Picker) forwardedProto(r *http.Request) string {
+	if v := p.nearestProto(forwarded(r)); v != "" {
+		return v
+	}
+	protos := trimAll(headerList(r, "X-Forwarded-Proto"))
+	return p.nearestProto(protos, trimAll(forwardedHops(r)))
+}
+
+// nearestProto returns the proto of the trusted proxy nearest the
+// client. The hops are walked from right to left, as in remoteIP,
+// as protos to the left of it may be spoofed.
+func (p *Picker) nearestProto(protos, hops []string) string {
+	i := len(protos) - 1
+	for j := len(hops) - 1; i > 0 && j >= 0; j-- {
+		if !p.isTrustedHop(hops[j]) {
+			break
+		}
+		i--
+	}
+	if i < 0 {
+		return ""
+	}
+	return strings.ToLower(protos[i])
+}
+
+// isTrustedHop returns true if hop is the address of a trusted
+// proxy.
+func (p *Picker) isTrustedHop(hop string) bool {
+	addr, err := netip.ParseAddr(hop)
+	return err == nil && p.isTrusted(addr.Unmap())
+}
+
+// forwarded returns the proto and address of each element of the
+// Forwarded headers.
+func forwarded(r *http.Request) (protos, hops []string) {
+	for _, element := range headerList(r, "Forwarded") {
+		pairs := forwardedPairs(element)
+		protos = append(protos, pairs["proto"])
+		hops = append(hops, forwardedNode(pairs["for"]))
+	}
+	return protos, hops
+}
+
+// forwardedPairs returns the parameters of a Forwarded element by
+// lower case name, with quotes removed.
+func forwardedPairs(element string) map[string]string {
+	pairs := make(map[string]string)
+	for _, pair := range strings.Split(element, ";") {
+		k, v, _ := strings.Cut(strings.TrimSpace(pair), "=")
+		pairs[strings.ToLower(k)] = strings.Trim(v, `"`)
+	}
+	return pairs
+}
+
+// forwardedNode returns the address of a Forwarded node, e.g.
+// 192.0.2.1 of "[2001:db8::1]:4711" or 192.0.2.1:80.
+func forwardedNode(node string) string {
+	if ap, err := netip.ParseAddrPort(node); err == nil {
+		return ap.Addr().String()
+	}
+	return strings.Trim(node, "[]")
+}
+
+// headerList returns the comma separated values of all headers with
+// the given name.
+func headerList(r *http.Request, name string) []string {
+	v := strings.Join(r.Header.Values(name), ",")
+	if v == "" {
+		return nil
+	}
+	return strings.Split(v, ",")
+}
+
+func trimAll(values []string) []string {
+	for i, v := range values {
+		values[i] = strings.TrimSpace(v)
+	}
+	return values
+}
@@ -0,0 +1,179 @@
+package xr
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+)
+
+func ExamplePick_hostAndPort() {
+	var x struct {
+		Tenant string `request:"hostname"`
+		Port   int    `request:"port"`
+	}
+	r := httptest.NewRequest("GET", "http://acme.example.com:8080/", nil)
+	_ = Pick(&x, r)
+	fmt.Println(x.Tenant, x.Port)
+	// output:
+	// acme.example.com 8080
+}
+
+func ExamplePick_requestMetadata() {
+	var x struct {
+		Method string `request:"method"`
+		Host   string `request:"host"`
+		URI    string `request:"uri"`
+	}
+	r := httptest.NewRequest("PUT", "http://example.com:8080/a?b=c", nil)
+	_ = Pick(&x, r)
+	fmt.Println(x.Method, x.Host, x.URI)
+	// output:
+	// PUT example.com:8080 /a?b=c
+}
+
+func Test_splitHost(t *testing.T) {
+	cases := []struct {
+		host, hostname, port string
+	}{
+		{"example.com", "example.com", ""},
+		{"example.com:80", "example.com", "80"},
+		{"[::1]:8080", "::1", "8080"},
+		{"[::1]", "::1", ""},
+		{"", "", ""},
+	}
+	for _, c := range cases {
+		hostname, port := splitHost(c.host)
+		if hostname != c.hostname || port != c.port {
+			t.Errorf("%q: got %q %q", c.host, hostname, port)
+		}
+	}
+}
+
+func Test_readMeta_unknown(t *testing.T) {
+	defer catchPanic(t)
+	var x struct {
+		V string `request:"jibberish"`
+	}
+	r := httptest.NewRequest("GET", "/", http.NoBody)
+	_ = Pick(&x, r)
+}
+
+func Test_requestHost_outgoing(t *testing.T) {
+	r, _ := http.NewRequest("GET", "http://example.com:90/", nil)
+	r.Host = ""
+	var x struct {
+		Port string `request:"port"`
+	}
+	_ = Pick(&x, r)
+	if x.Port != "90" {
+		t.Error("got", x.Port)
+	}
+}
+
+func ExamplePicker_SetTrustedProxies() {
+	p := NewPicker()
+	p.SetTrustedProxies(netip.MustParsePrefix("10.0.0.0/8"))
+
+	var x struct {
+		Scheme string `request:"scheme"`
+	}
+	r := httptest.NewRequest("GET", "/", http.NoBody)
+	r.RemoteAddr = "10.1.2.3:4711"
+	r.Header.Set("X-Forwarded-Proto", "https")
+	_ = p.Pick(&x, r)
+	fmt.Println(x.Scheme)
+
+	// untrusted
+	r.RemoteAddr = "192.0.2.1:4711"
+	_ = p.Pick(&x, r)
+	fmt.Println(x.Scheme)
+	// output:
+	// https
+	// http
+}
+
+func TestPicker_scheme(t *testing.T) {
+	p := NewPicker()
+	p.SetTrustedProxies(netip.MustParsePrefix("192.0.2.0/24"))
+	const client, proxy = "203.0.113.9", "192.0.2.7"
+	cases := []struct {
+		remote, forwarded, xproto, xfor string
+		tls                             bool
+		exp                             string
+	}{
+		{"192.0.2.1:1", `for=x;proto="HTTPS"`, "", "", false, "https"},
+		{"192.0.2.1:1", "for=x", "https, http", "", false, "http"},
+		{"192.0.2.1", "", "https", "", false, "https"},
+		{"192.0.2.1:1", "", "", "", true, "https"},
+		{"198.51.100.1:1", "proto=https", "", "", false, "http"},
+		{"198.51.100.1:1", "", "http", "", true, "https"},
+		{"jibberish", "", "https", "", false, "http"},
+		// spoofed by the client in front of the first proxy
+		{"192.0.2.1:1", "", "https, http", client, false, "http"},
+		{"192.0.2.1:1", "", "https, http, http",
+			client + ", " + proxy, false, "http"},
+		{"192.0.2.1:1", "", "https, http",
+			client + "," + proxy, false, "https"},
+		{"192.0.2.1:1", "proto=https, for=" + client + ";proto=http, " +
+			"for=" + proxy + ";proto=http", "", "", false, "http"},
+	}
+	for _, c := range cases {
+		r := httptest.NewRequest("GET", "/", http.NoBody)
+		r.RemoteAddr = c.remote
+		r.Header.Set("Forwarded", c.forwarded)
+		r.Header.Set("X-Forwarded-Proto", c.xproto)
+		r.Header.Set("X-Forwarded-For", c.xfor)
+		if c.tls {
+			r.TLS = &tls.ConnectionState{}
+		}
+		if got := p.scheme(r); got != c.exp {
+			t.Errorf("%+v: got %s", c, got)
+		}
+	}
+}
+
+func ExamplePick_remoteIP() {
+	p := NewPicker()
+	p.SetTrustedProxies(netip.MustParsePrefix("10.0.0.0/8"))
+
+	var x struct {
+		Client netip.Addr `request:"remoteip"`
+	}
+	r := httptest.NewRequest("GET", "/", http.NoBody)
+	r.RemoteAddr = "10.1.2.3:4711"
+	r.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.9")
+	_ = p.Pick(&x, r)
+	fmt.Println(x.Client)
+	// output:
+	// 203.0.113.7
+}
+
+func TestPicker_remoteIP(t *testing.T) {
+	p := NewPicker()
+	p.SetTrustedProxies(netip.MustParsePrefix("192.0.2.0/24"))
+	cases := []struct {
+		remote, xff, realIP, exp string
+	}{
+		{"198.51.100.1:1", "203.0.113.7", "", "198.51.100.1"},
+		{"192.0.2.1:1", "203.0.113.7", "", "203.0.113.7"},
+		{"192.0.2.1:1", "1.1.1.1, 203.0.113.7", "", "203.0.113.7"},
+		{"192.0.2.1:1", "203.0.113.7, 192.0.2.9", "", "203.0.113.7"},
+		{"192.0.2.1:1", "192.0.2.8, 192.0.2.9", "", "192.0.2.8"},
+		{"192.0.2.1:1", "jibberish", "", "192.0.2.1"},
+		{"192.0.2.1:1", "", "203.0.113.7", "203.0.113.7"},
+		{"192.0.2.1", "", "", "192.0.2.1"},
+		{"jibberish", "203.0.113.7", "", ""},
+	}
+	for _, c := range cases {
+		r := httptest.NewRequest("GET", "/", http.NoBody)
+		r.RemoteAddr = c.remote
+		r.Header.Set("X-Forwarded-For", c.xff)
+		r.Header.Set("X-Real-IP", c.realIP)
+		if got := p.remoteIP(r); got != c.exp {
+			t.Errorf("%+v: got %s", c, got)
+		}
+	}
+}
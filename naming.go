@@ -0,0 +1,75 @@
+package xr
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// SetNaming sets the func converting field names for source tags
+// with an empty name, e.g. `query:""`. Default is [LowerCamelCase].
+func (p *Picker) SetNaming(fn func(string) string) {
+	p.naming = fn
+}
+
+// tagName returns the name of the given source tag of field and true
+// if found. Empty names are inferred from the field name.
+func (p *Picker) tagName(field reflect.StructField, source string) (
+	string, bool,
+) {
+	name, found := field.Tag.Lookup(source)
+	if found && name == "" {
+		name = p.naming(field.Name)
+	}
+	return name, found
+}
+
+// LowerCamelCase returns name with the first word in lower case,
+// e.g. UserID becomes userID.
+func LowerCamelCase(name string) string {
+	w := words(name)
+	if len(w) == 0 {
+		return ""
+	}
+	w[0] = strings.ToLower(w[0])
+	return strings.Join(w, "")
+}
+
+// SnakeCase returns name as lower case words separated by _, e.g.
+// UserID becomes user_id.
+func SnakeCase(name string) string {
+	return strings.ToLower(strings.Join(words(name), "_"))
+}
+
+// KebabCase returns name as lower case words separated by -, e.g.
+// UserID becomes user-id.
+func KebabCase(name string) string {
+	return strings.ToLower(strings.Join(words(name), "-"))
+}
+
+// words splits a camel case name into words, keeping abbreviations
+// together, e.g. HTTPServer becomes HTTP and Server.
+func words(name string) []string {
+	var result []string
+	r := []rune(name)
+	start := 0
+	for i := 1; i < len(r); i++ {
+		if wordStart(r, i) {
+			result = append(result, string(r[start:i]))
+			start = i
+		}
+	}
+	if start < len(r) {
+		result = append(result, string(r[start:]))
+	}
+	return result
+}
+
+// wordStart returns true if an upper case r[i] begins a new word.
+func wordStart(r []rune, i int) bool {
+	if !unicode.IsUpper(r[i]) {
+		return false
+	}
+	endOfAbbr := i+1 < len(r) && unicode.IsLower(r[i+1])
+	return !unicode.IsUpper(r[i-1]) || endOfAbbr
+}
@@ -0,0 +1,63 @@
+package xr
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExamplePicker_SetNaming() {
+	var x struct {
+		PageSize int    `query:""`
+		SortBy   string `query:""`
+	}
+	r := httptest.NewRequest("GET", "/?pageSize=10&sortBy=name", nil)
+	_ = Pick(&x, r)
+	fmt.Println(x.PageSize, x.SortBy)
+
+	p := NewPicker()
+	p.SetNaming(SnakeCase)
+	r = httptest.NewRequest("GET", "/?page_size=20&sort_by=age", nil)
+	_ = p.Pick(&x, r)
+	fmt.Println(x.PageSize, x.SortBy)
+	// output:
+	// 10 name
+	// 20 age
+}
+
+func TestNaming(t *testing.T) {
+	cases := []struct {
+		name, camel, snake, kebab string
+	}{
+		{"UserID", "userID", "user_id", "user-id"},
+		{"HTTPServer", "httpServer", "http_server", "http-server"},
+		{"Name", "name", "name", "name"},
+		{"ID", "id", "id", "id"},
+		{"Page2Size", "page2Size", "page2_size", "page2-size"},
+		{"", "", "", ""},
+	}
+	for _, c := range cases {
+		got := []string{
+			LowerCamelCase(c.name), SnakeCase(c.name), KebabCase(c.name),
+		}
+		exp := []string{c.camel, c.snake, c.kebab}
+		if fmt.Sprint(got) != fmt.Sprint(exp) {
+			t.Errorf("%s: got %v, exp %v", c.name, got, exp)
+		}
+	}
+}
+
+func TestPick_inferredHeader(t *testing.T) {
+	p := NewPicker()
+	p.SetNaming(KebabCase)
+	var x struct {
+		RequestID string `header:""`
+	}
+	r := httptest.NewRequest("GET", "/", http.NoBody)
+	r.Header.Set("Request-Id", "abc")
+	_ = p.Pick(&x, r)
+	if x.RequestID != "abc" {
+		t.Error("got", x.RequestID)
+	}
+}
@@ -0,0 +1,107 @@
+package xr
+
+import (
+	"net/http"
+	"reflect"
+)
+
+// isNested returns true if field is an exported, or embedded,
+// struct, or exported embedded struct pointer, without a setter, i.e.
+// its fields are picked recursively.
+// Structs with a source tag must have fields with source tags to be
+// nested, otherwise they are picked as values.
+func (p *Picker) isNested(field reflect.StructField) bool {
+	t := nestedType(field)
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	_, found := p.typeSetter(t)
+	return !found && (field.IsExported() || field.Anonymous) &&
+		p.hasNestedSources(field)
+}
+
+// hasNestedSources returns true if field has no source tag or if any
+// field of its struct type, or their nested structs, has one.
+func (p *Picker) hasNestedSources(field reflect.StructField) bool {
+	return !hasReaderTag(field) || p.hasSources(nestedType(field))
+}
+
+// nestedType returns the type of field, or the struct type it points
+// to if field is an exported embedded pointer, e.g. *Pagination.
+func nestedType(field reflect.StructField) reflect.Type {
+	t := field.Type
+	embedded := field.Anonymous && field.IsExported()
+	if embedded && t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	return t
+}
+
+// nestedPtr returns a pointer to the struct of nested field v. The
+// returned value is invalid if v is a nil embedded pointer.
+func nestedPtr(v reflect.Value) reflect.Value {
+	if v.Kind() != reflect.Pointer {
+		return v.Addr()
+	}
+	if v.IsNil() {
+		return reflect.Value{}
+	}
+	return v
+}
+
+// allocNested allocates the struct of v if it is a nil embedded
+// pointer.
+func allocNested(v reflect.Value) {
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		v.Set(reflect.New(v.Type().Elem()))
+	}
+}
+
+// hasSources returns true if a field of struct t, or of its nested
+// structs, has a source tag.
+func (p *Picker) hasSources(t reflect.Type) bool {
+	obj := reflect.New(t)
+	for i := 0; i < t.NumField(); i++ {
+		field := p.field(obj, i)
+		if hasReaderTag(field) {
+			return true
+		}
+		t := nestedType(field)
+		if t.Kind() == reflect.Struct && p.hasSources(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// pickNested picks the fields of struct field i of obj. Source tags
+// of the field are prefixes of the names of the nested source tags,
+// e.g. `query:"address."` picks `query:"street"` from
+// address.street.
+func (p *Picker) pickNested(obj reflect.Value, i int, r *http.Request,
+	prefix map[string]string,
+) error {
+	field := p.field(obj, i)
+	nested := make(map[string]string)
+	for kind := range p.readers {
+		nested[kind] = prefix[kind] + field.Tag.Get(kind)
+	}
+	v := obj.Elem().Field(i)
+	allocNested(v)
+	err := p.pickStruct(nestedPtr(v), r, nested)
+	if err != nil {
+		return err
+	}
+	return p.validate(obj, i, nestedSource(field, prefix))
+}
+
+// nestedSource returns the source of a nested struct field, e.g.
+// query[address.], or body if it has no source tag.
+func nestedSource(field reflect.StructField, prefix map[string]string) source {
+	for _, kind := range readerKinds {
+		if name, found := field.Tag.Lookup(kind); found {
+			return source{kind: kind, name: prefix[kind] + name}
+		}
+	}
+	return bodySource
+}
@@ -0,0 +1,115 @@
+package xr
+
+import (
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExamplePick_nested() {
+	type Address struct {
+		Street string `query:"street"`
+		Zip    int    `query:"zip"`
+	}
+	var x struct {
+		Home Address `query:"home."`
+		Work Address `query:"work."`
+		Page struct {
+			Size int `query:"size"`
+		}
+	}
+	r := httptest.NewRequest(
+		"GET", "/?home.street=Elm&work.zip=12345&size=10", nil,
+	)
+	_ = Pick(&x, r)
+	fmt.Printf("%+v\n%+v\n%+v", x.Home, x.Work, x.Page)
+	// output:
+	// {Street:Elm Zip:0}
+	// {Street: Zip:12345}
+	// {Size:10}
+}
+
+func TestPick_nestedError(t *testing.T) {
+	var x struct {
+		Outer struct {
+			Inner struct {
+				N int `header:"n"`
+			} `header:"inner-"`
+		} `header:"x-outer-"`
+	}
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("x-outer-inner-n", "one")
+	err := Pick(&x, r)
+	var e *PickError
+	if !errors.As(err, &e) || e.SourceName != "x-outer-inner-n" {
+		t.Error(err)
+	}
+}
+
+func TestPick_nestedSource(t *testing.T) {
+	type Range struct {
+		Min int `query:"min"`
+		Max int `query:"max"`
+	}
+	p := NewPicker()
+	UseValidatorFor(p, func(v Range) error {
+		if v.Min > v.Max {
+			return errors.New("min above max")
+		}
+		return nil
+	})
+	var x struct {
+		Price Range `query:"price."`
+	}
+	r := httptest.NewRequest("GET", "/?price.min=9&price.max=1", nil)
+	var e *PickError
+	if err := p.Pick(&x, r); !errors.As(err, &e) {
+		t.Fatal(err)
+	}
+	if e.Source != "query[price.]" {
+		t.Error(e.Source)
+	}
+}
+
+func ExamplePick_embedded() {
+	type Pagination struct {
+		Page int `query:"page"`
+		Size int `query:"size"`
+	}
+	type auth struct {
+		Token string `header:"authorization"`
+	}
+	var x struct {
+		Pagination
+		auth
+		Search string `query:"q"`
+	}
+	r := httptest.NewRequest("GET", "/?q=go&page=2&size=20", nil)
+	r.Header.Set("authorization", "Bearer abc")
+	_ = Pick(&x, r)
+	fmt.Println(x.Search, x.Page, x.Size, x.Token)
+	// output:
+	// go 2 20 Bearer abc
+}
+
+func TestPick_embeddedPointer(t *testing.T) {
+	type Pagination struct {
+		Page int `query:"page"`
+	}
+	var x struct {
+		*Pagination
+		Search string `query:"q"`
+	}
+	r := httptest.NewRequest("GET", "/?q=go&page=2", nil)
+	if err := Pick(&x, r); err != nil || x.Pagination == nil {
+		t.Fatal(x, err)
+	}
+	if x.Page != 2 {
+		t.Error(x.Page)
+	}
+	x.Pagination = nil
+	if _, err := NewRequest("GET", "/", &x); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,387 @@
+package xr
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// NewRequest returns a request to url built from the fields of
+// struct src, the reverse of Pick. Fields tagged path replace
+// wildcards in url, e.g. {id}, and fields tagged query, header,
+// cookie and form are added to the respective part of the
+// request, also those of nested structs with the prefixes of their
+// source tags. A field tagged `body:""`, `body:"text"` or
+// `body:"binary"` is sent as the body as is. Otherwise form fields
+// are sent as an urlencoded body and fields without source tags as
+// a JSON body. Zero values are left out, as are fields capturing all
+// values, e.g. `header:"*"`. Wildcards in url without a value result
+// in an error.
+func (p *Picker) NewRequest(method, url string, src any) (
+	*http.Request, error,
+) {
+	v := reflect.Indirect(reflect.ValueOf(src))
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("NewRequest %T: not a struct", src)
+	}
+	obj := reflect.New(v.Type())
+	obj.Elem().Set(v)
+	o := outgoing{
+		path:   make(map[string]string),
+		query:  make(map[string][]string),
+		header: make(http.Header),
+		form:   make(map[string][]string),
+	}
+	p.addFields(&o, obj, map[string]string{}, true)
+	return o.request(method, url)
+}
+
+// outgoing collects parts of a request built by NewRequest.
+type outgoing struct {
+	path    map[string]string
+	query   url.Values
+	header  http.Header
+	cookies []*http.Cookie
+	form    url.Values
+	body    []reflect.StructField
+	values  []reflect.Value
+
+	// raw body of a field tagged body and its content-type
+	raw     io.Reader
+	rawType string
+
+	errs []error
+}
+
+// addFields adds the fields of the struct obj points to. Names of
+// source tags are prefixed by source, see pickNested. Fields without
+// source tags are added to the body if body is true. Nothing is
+// added if obj is invalid, e.g. a nil embedded pointer.
+func (p *Picker) addFields(o *outgoing, obj reflect.Value,
+	prefix map[string]string, body bool,
+) {
+	if !obj.IsValid() {
+		return
+	}
+	for i := 0; i < obj.Elem().NumField(); i++ {
+		p.addField(o, obj, i, prefix, body)
+	}
+}
+
+// addField adds field i of obj to the part of o given by its tags.
+func (p *Picker) addField(o *outgoing, obj reflect.Value, i int,
+	prefix map[string]string, body bool,
+) {
+	field := p.field(obj, i)
+	if _, _, capture := captureTag(field.Tag); capture {
+		return
+	}
+	if p.isNested(field) {
+		p.addNested(o, obj, i, prefix, body)
+		return
+	}
+	if field.IsExported() {
+		p.addValue(o, field, obj.Elem().Field(i), prefix, body)
+	}
+}
+
+// addNested adds the fields of nested struct field i of obj with
+// their prefixes. Embedded structs are flattened in the body, other
+// nested structs without source tags are added to it as a whole.
+func (p *Picker) addNested(o *outgoing, obj reflect.Value, i int,
+	prefix map[string]string, body bool,
+) {
+	field := p.field(obj, i)
+	v := obj.Elem().Field(i)
+	whole := body && !field.Anonymous && !p.hasSourceTag(field)
+	if whole {
+		o.body = append(o.body, field)
+		o.values = append(o.values, v)
+	}
+	nested := nestedPrefix(field, prefix, outgoingSources)
+	p.addFields(o, nestedPtr(v), nested, body && field.Anonymous)
+}
+
+// addValue adds value v of field to the part of o given by its tags.
+func (p *Picker) addValue(o *outgoing, field reflect.StructField,
+	v reflect.Value, prefix map[string]string, body bool,
+) {
+	if name, found := field.Tag.Lookup("body"); found {
+		o.addBody(field, name, v)
+		return
+	}
+	if source, name, found := p.outgoingSource(field); found {
+		o.add(source, prefix[source]+name, outgoingValues(v))
+		return
+	}
+	if body && !p.hasSourceTag(field) {
+		o.body = append(o.body, field)
+		o.values = append(o.values, v)
+	}
+}
+
+// addBody sets the raw body of o to value v of field tagged
+// `body:"<name>"`.
+func (o *outgoing) addBody(field reflect.StructField, name string,
+	v reflect.Value,
+) {
+	ct, found := bodyTypes[name]
+	if !found {
+		o.fail(fmt.Errorf("%s: body %q unsupported", field.Name, name))
+		return
+	}
+	r, err := bodyReader(v)
+	if err != nil {
+		o.fail(fmt.Errorf("%s: %w", field.Name, err))
+		return
+	}
+	if r != nil {
+		o.raw, o.rawType = r, ct
+	}
+}
+
+// bodyTypes are content-types of body tags sent as is.
+var bodyTypes = map[string]string{
+	"":       "",
+	"text":   "text/plain; charset=utf-8",
+	"binary": "application/octet-stream",
+}
+
+// bodyReader returns a reader of v, nil if zero.
+func bodyReader(v reflect.Value) (io.Reader, error) {
+	switch {
+	case v.IsZero():
+		return nil, nil
+
+	case isBytes(v.Type()):
+		return bytes.NewReader(v.Bytes()), nil
+
+	case v.Kind() == reflect.String:
+		return strings.NewReader(v.String()), nil
+
+	case v.Type() == readerType:
+		return v.Interface().(io.Reader), nil
+	}
+	return nil, fmt.Errorf("body %v: unsupported", v.Type())
+}
+
+func (o *outgoing) fail(err error) {
+	o.errs = append(o.errs, err)
+}
+
+// outgoingSource returns the first source field is tagged with and
+// its name.
+func (p *Picker) outgoingSource(field reflect.StructField) (
+	string, string, bool,
+) {
+	for _, source := range outgoingSources {
+		if name, found := p.tagName(field, source); found {
+			return source, name, true
+		}
+	}
+	return "", "", false
+}
+
+var outgoingSources = []string{"path", "query", "header", "cookie", "form"}
+
+// hasSourceTag returns true if field is read from any source other
+// than the body.
+func (p *Picker) hasSourceTag(field reflect.StructField) bool {
+	for source := range p.readers {
+		if _, found := field.Tag.Lookup(source); found {
+			return true
+		}
+	}
+	_, found := field.Tag.Lookup("part")
+	return found || isContextField(field)
+}
+
+func (o *outgoing) add(source, name string, values []string) {
+	for _, val := range values {
+		outgoingAdders[source](o, name, val)
+	}
+}
+
+// outgoingAdders add a value by name to each source of a request.
+var outgoingAdders = map[string]func(o *outgoing, name, val string){
+	"path": func(o *outgoing, name, val string) {
+		o.path[name] = val
+	},
+	"query": func(o *outgoing, name, val string) {
+		o.query.Add(name, val)
+	},
+	"header": func(o *outgoing, name, val string) {
+		o.header.Add(name, val)
+	},
+	"cookie": func(o *outgoing, name, val string) {
+		c := &http.Cookie{Name: name, Value: val}
+		o.cookies = append(o.cookies, c)
+	},
+	"form": func(o *outgoing, name, val string) {
+		o.form.Add(name, val)
+	},
+}
+
+// outgoingValues returns v formatted as strings, one per element of
+// slices. Zero values and nil pointers result in none.
+func outgoingValues(v reflect.Value) []string {
+	v = reflect.Indirect(v)
+	if !v.IsValid() || v.IsZero() {
+		return nil
+	}
+	if v.Kind() != reflect.Slice || v.Type() == bytesType {
+		return []string{format(v)}
+	}
+	return formatAll(v)
+}
+
+// formatAll returns each element of slice v as text.
+func formatAll(v reflect.Value) []string {
+	values := make([]string, v.Len())
+	for i := range values {
+		values[i] = format(v.Index(i))
+	}
+	return values
+}
+
+// format returns v as text, using encoding.TextMarshaler if
+// implemented.
+func format(v reflect.Value) string {
+	if v.Type() == bytesType {
+		return string(v.Bytes())
+	}
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		text, _ := m.MarshalText()
+		return string(text)
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+func (o *outgoing) request(method, rawURL string) (*http.Request, error) {
+	if err := errors.Join(o.errs...); err != nil {
+		return nil, fmt.Errorf("NewRequest: %w", err)
+	}
+	u, err := o.url(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	body, ct, err := o.encodeBody()
+	if err != nil {
+		return nil, err
+	}
+	r, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	o.setHeader(r, ct)
+	return r, nil
+}
+
+// url returns rawURL with wildcards expanded and query values
+// added.
+func (o *outgoing) url(rawURL string) (*url.URL, error) {
+	expanded := o.expand(rawURL)
+	if w := wildcard.FindString(expanded); w != "" {
+		return nil, fmt.Errorf("NewRequest %s: no value for %s", rawURL, w)
+	}
+	u, err := url.Parse(expanded)
+	if err != nil {
+		return nil, err
+	}
+	query := u.Query()
+	for name, values := range o.query {
+		query[name] = append(query[name], values...)
+	}
+	u.RawQuery = query.Encode()
+	return u, nil
+}
+
+// wildcard matches wildcards of patterns, e.g. {id} or {path...}
+var wildcard = regexp.MustCompile(`\{\w+(\.\.\.)?\}`)
+
+// expand replaces wildcards of path values in rawURL, e.g. {id} or
+// {path...}.
+func (o *outgoing) expand(rawURL string) string {
+	pairs := make([]string, 0, 4*len(o.path))
+	for name, val := range o.path {
+		pairs = append(pairs,
+			"{"+name+"}", url.PathEscape(val),
+			"{"+name+"...}", escapeSegments(val),
+		)
+	}
+	return strings.NewReplacer(pairs...).Replace(rawURL)
+}
+
+// escapeSegments escapes each segment of path.
+func escapeSegments(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+// encodeBody returns the raw body, the urlencoded form or fields
+// without source tags as JSON, and the content-type.
+func (o *outgoing) encodeBody() (io.Reader, string, error) {
+	if o.raw != nil {
+		return o.raw, o.rawType, nil
+	}
+	if len(o.form) > 0 {
+		body := strings.NewReader(o.form.Encode())
+		return body, "application/x-www-form-urlencoded", nil
+	}
+	if len(o.body) == 0 {
+		return nil, "", nil
+	}
+	v, err := o.bodyValue()
+	if err != nil {
+		return nil, "", err
+	}
+	data, err := json.Marshal(v.Interface())
+	return bytes.NewReader(data), "application/json", err
+}
+
+// bodyValue returns a struct value of only the body fields. Fields
+// with the same name, e.g. of flattened embedded structs, result in
+// an error.
+func (o *outgoing) bodyValue() (reflect.Value, error) {
+	fields := make([]reflect.StructField, len(o.body))
+	seen := make(map[string]bool)
+	for i, f := range o.body {
+		if seen[f.Name] {
+			return reflect.Value{}, fmt.Errorf(
+				"NewRequest: duplicate body field %s", f.Name,
+			)
+		}
+		seen[f.Name] = true
+		fields[i] = reflect.StructField{
+			Name: f.Name, Type: f.Type, Tag: f.Tag,
+		}
+	}
+	v := reflect.New(reflect.StructOf(fields)).Elem()
+	for i, val := range o.values {
+		v.Field(i).Set(val)
+	}
+	return v, nil
+}
+
+func (o *outgoing) setHeader(r *http.Request, ct string) {
+	for name, values := range o.header {
+		r.Header[name] = values
+	}
+	if ct != "" {
+		r.Header.Set("content-type", ct)
+	}
+	for _, c := range o.cookies {
+		r.AddCookie(c)
+	}
+}
@@ -0,0 +1,167 @@
+package xr
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func ExampleNewRequest() {
+	type Order struct {
+		ID    int      `path:"id"`
+		Tags  []string `query:"tag"`
+		Token string   `header:"x-token"`
+		Note  string   `json:"note"`
+	}
+	src := Order{ID: 7, Tags: []string{"a", "b"}, Token: "t", Note: "hi"}
+	r, _ := NewRequest("PUT", "http://x.example/orders/{id}", &src)
+	body, _ := io.ReadAll(r.Body)
+	fmt.Println(r.Method, r.URL)
+	fmt.Println(r.Header.Get("x-token"), r.Header.Get("content-type"))
+	fmt.Println(string(body))
+
+	// and back on the server side
+	r, _ = NewRequest("PUT", "/orders/{id}", &src)
+	r.SetPathValue("id", "7")
+	var dst Order
+	_ = Pick(&dst, r)
+	fmt.Println(reflect.DeepEqual(dst, src))
+	// output:
+	// PUT http://x.example/orders/7?tag=a&tag=b
+	// t application/json
+	// {"note":"hi"}
+	// true
+}
+
+func TestNewRequest(t *testing.T) {
+	type x struct {
+		Path    string      `path:"path"`
+		Session string      `cookie:"session"`
+		Name    string      `form:"name"`
+		When    time.Time   `query:"when"`
+		Skip    *int        `query:"skip"`
+		Host    string      `request:"hostname"`
+		Meta    http.Header `header:"*"`
+		private string
+	}
+	when := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	src := x{
+		Path: "a b/c", Session: "s", Name: "n", When: when, Host: "h",
+		Meta: http.Header{"X": {"y"}}, private: "p",
+	}
+	r, err := NewRequest("POST", "/files/{path...}", src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(r.Body)
+	got := fmt.Sprint(r.URL, " ", r.Header, " ", string(body))
+	exp := "/files/a%20b/c?when=2024-01-02T03%3A04%3A05Z " +
+		"map[Content-Type:[application/x-www-form-urlencoded] " +
+		"Cookie:[session=s]] name=n"
+	if got != exp {
+		t.Errorf("\ngot %s\nexp %s", got, exp)
+	}
+}
+
+func TestNewRequest_nested(t *testing.T) {
+	type Paging struct {
+		Page int `query:"page"`
+	}
+	type Address struct {
+		Street string `query:"street"`
+		City   string `json:"city"`
+	}
+	type x struct {
+		Paging
+		Address Address `query:"address."`
+		Billing Address
+	}
+	src := x{
+		Paging:  Paging{Page: 2},
+		Address: Address{Street: "s1", City: "c1"},
+		Billing: Address{Street: "s2", City: "c2"},
+	}
+	r, err := NewRequest("POST", "/", src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(r.Body)
+	got := fmt.Sprint(r.URL, " ", string(body))
+	exp := "/?address.street=s1&page=2&street=s2 " +
+		`{"Billing":{"Street":"s2","city":"c2"}}`
+	if got != exp {
+		t.Errorf("\ngot %s\nexp %s", got, exp)
+	}
+}
+
+func TestNewRequest_errors(t *testing.T) {
+	type A struct{ Name string }
+	type B struct{ Name string }
+	var dup struct {
+		A
+		B
+	}
+	var csv struct {
+		Rows []byte `body:"csv"`
+	}
+	num := struct {
+		N int `body:"text"`
+	}{N: 1}
+	var id struct {
+		ID int `path:"id"`
+	}
+	fn := struct{ F func() }{F: func() {}}
+	var src struct{}
+	cases := []struct {
+		method, url string
+		src         any
+	}{
+		{"GET", "/", 1},
+		{"GET", ":", src},
+		{"bad method", "/", src},
+		{"GET", "/", fn},
+		{"GET", "/items/{id}", id},
+		{"POST", "/", dup},
+		{"POST", "/", csv},
+		{"POST", "/", num},
+	}
+	for _, c := range cases {
+		if _, err := NewRequest(c.method, c.url, c.src); err == nil {
+			t.Errorf("%s %s %T: expect error", c.method, c.url, c.src)
+		}
+	}
+}
+
+func TestNewRequest_body(t *testing.T) {
+	cases := []struct {
+		src  any
+		body string
+		ct   string
+	}{
+		{struct {
+			Text string `body:"text"`
+			Page int    `query:"page"`
+		}{Text: "hi"}, "hi", "text/plain; charset=utf-8"},
+		{struct {
+			Data []byte `body:"binary"`
+		}{Data: []byte{1, 2}}, "\x01\x02", "application/octet-stream"},
+		{struct {
+			Raw  io.Reader `body:""`
+			Note string    `json:"note"`
+		}{Raw: strings.NewReader("raw")}, "raw", ""},
+	}
+	for _, c := range cases {
+		r, err := NewRequest("POST", "/", c.src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != c.body || r.Header.Get("content-type") != c.ct {
+			t.Errorf("%T\ngot %q %q", c.src, body, r.Header.Get("content-type"))
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package xr
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// RegisterNormalizer registers fn for normalizing string values of
+// fields tagged `normalize:"NAME"` before they're set, e.g.
+// adapt/norm registers Unicode normalization forms. Safe to call
+// while picking. Panics if name is already registered.
+func (p *Picker) RegisterNormalizer(name string, fn func(string) string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, found := p.normalizers[name]; found {
+		panic(fmt.Sprintf("RegisterNormalizer(%q): already exists", name))
+	}
+	p.normalizers[name] = fn
+}
+
+// normalize returns v normalized if field has a normalize tag.
+func (p *Picker) normalize(field reflect.StructField, v string) (
+	string, error,
+) {
+	name, found := field.Tag.Lookup("normalize")
+	if !found {
+		return v, nil
+	}
+	fn, found := lookup(p, p.normalizers, name)
+	if !found {
+		return "", fmt.Errorf("normalize %s: unknown", name)
+	}
+	return fn(v), nil
+}
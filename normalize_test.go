@@ -0,0 +1,31 @@
+package xr
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPicker_RegisterNormalizer(t *testing.T) {
+	p := NewPicker()
+	p.RegisterNormalizer("lower", strings.ToLower)
+	var x struct {
+		Tag string `query:"tag" normalize:"lower"`
+	}
+	r := httptest.NewRequest("GET", "/?tag=GoLang", nil)
+	if err := p.Pick(&x, r); err != nil || x.Tag != "golang" {
+		t.Error(x.Tag, err)
+	}
+	defer catchPanic(t)
+	p.RegisterNormalizer("lower", strings.ToLower)
+}
+
+func TestPicker_normalize_unknown(t *testing.T) {
+	var x struct {
+		Tag string `query:"tag" normalize:"NFX"`
+	}
+	r := httptest.NewRequest("GET", "/?tag=go", nil)
+	if err := NewPicker().Pick(&x, r); err == nil {
+		t.Error("expect error")
+	}
+}
@@ -0,0 +1,110 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"reflect"
+	"sort"
+
+	"github.com/gregoryv/xr"
+)
+
+// Bind returns a binding of op to the type of v, a struct or
+// pointer to struct. The returned error lists divergence between
+// the parameters of op and the source tags of v. The binding is
+// usable even if there are divergences.
+func Bind(p *xr.Picker, v any, op *Operation) (*Binding, error) {
+	fields := sourceFields(p, v)
+	b := Binding{picker: p, op: op, fields: fields}
+	return &b, diverge(fields, op)
+}
+
+// Binding validates parameters of an operation before picking.
+type Binding struct {
+	picker *xr.Picker
+	op     *Operation
+
+	// field names by source, e.g. query[name]
+	fields map[string]string
+}
+
+// Pick validates the request against the parameters of the bound
+// operation and then picks it into dst.
+func (b *Binding) Pick(dst any, r *http.Request) error {
+	for _, param := range b.op.Parameters {
+		if err := b.validate(param, r); err != nil {
+			return err
+		}
+	}
+	return b.picker.Pick(dst, r)
+}
+
+// validate returns a *xr.PickError if the value of param in r
+// violates the parameter definition.
+func (b *Binding) validate(param *Parameter, r *http.Request) error {
+	v, found := b.paramValue(param, r)
+	if err := b.check(param, v, found); err != nil {
+		src := key(param.In, param.Name)
+		return xr.NewPickError(b.fields[src], src, err)
+	}
+	return nil
+}
+
+// diverge returns an error for each parameter not bound to a field
+// and each field bound to a missing parameter.
+func diverge(fields map[string]string, op *Operation) error {
+	unbound := maps.Clone(fields)
+	var errs []error
+	for _, param := range op.Parameters {
+		k := key(param.In, param.Name)
+		if _, found := unbound[k]; !found {
+			errs = append(errs, fmt.Errorf("%s: no field", k))
+		}
+		delete(unbound, k)
+	}
+	for _, k := range sortedKeys(unbound) {
+		errs = append(errs, fmt.Errorf("%s: %s not in operation", k, fields[k]))
+	}
+	return errors.Join(errs...)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sourceFields returns field names by source, e.g. query[name],
+// resolved by p.
+func sourceFields(p *xr.Picker, v any) map[string]string {
+	fields := make(map[string]string)
+	for _, f := range p.SourceFields(v, locations...) {
+		fields[key(f.Kind, f.Name)] = f.Field.Name
+	}
+	return fields
+}
+
+// locations of parameters in OpenAPI
+var locations = []string{"path", "query", "header", "cookie"}
+
+// key returns the source of a parameter, e.g. query[name]. Header
+// names are canonicalized.
+func key(in, name string) string {
+	if in == "header" {
+		name = http.CanonicalHeaderKey(name)
+	}
+	return in + "[" + name + "]"
+}
+
+func structType(v any) reflect.Type {
+	t := reflect.TypeOf(v)
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,112 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gregoryv/xr"
+)
+
+// paramValue returns the value of param in r and true if present.
+// Values are read by the picker, i.e. as when picking.
+func (b *Binding) paramValue(param *Parameter, r *http.Request) (
+	string, bool,
+) {
+	values := b.picker.Values(r, param.In, param.Name)
+	if len(values) == 0 {
+		return "", false
+	}
+	return values[0], true
+}
+
+// check returns an error if v violates the definition of param. The
+// constraints of the schema are checked by the picker as field tags.
+func (b *Binding) check(param *Parameter, v string, found bool) error {
+	switch {
+	case !found && param.Required:
+		return ErrRequired
+	case !found, param.Schema == nil:
+		return nil
+	}
+	value, err := typed(param.Schema.Type, v)
+	if err != nil {
+		return err
+	}
+	return b.picker.CheckValue(value, schemaTag(param.Schema))
+}
+
+// ErrRequired is the cause of errors for missing required parameters.
+var ErrRequired = xr.ErrRequired
+
+// typed returns v parsed as a value of the schema type.
+func typed(typ, v string) (any, error) {
+	switch typ {
+	case "integer":
+		return strconv.ParseInt(v, 10, 64)
+	case "number":
+		return strconv.ParseFloat(v, 64)
+	case "boolean":
+		return strconv.ParseBool(v)
+	}
+	return v, nil
+}
+
+// schemaTag returns the constraints of s as check tags, e.g.
+// `minimum:"1" maxLength:"20,runes"`.
+func schemaTag(s *Schema) reflect.StructTag {
+	var tags []string
+	for _, t := range schemaTags {
+		if v, found := t.fn(s); found {
+			tags = append(tags, t.tag+":"+strconv.Quote(v))
+		}
+	}
+	return reflect.StructTag(strings.Join(tags, " "))
+}
+
+// schemaTags return check tags from schema constraints, the reverse
+// of tagSchemas. Lengths of JSON Schema count characters.
+var schemaTags = []struct {
+	tag string
+	fn  func(s *Schema) (string, bool)
+}{
+	{"minimum", func(s *Schema) (string, bool) { return float(s.Minimum) }},
+	{"maximum", func(s *Schema) (string, bool) { return float(s.Maximum) }},
+	{"exclusiveMinimum", func(s *Schema) (string, bool) {
+		return s.ExclusiveMinimum.arg()
+	}},
+	{"exclusiveMaximum", func(s *Schema) (string, bool) {
+		return s.ExclusiveMaximum.arg()
+	}},
+	{"minLength", func(s *Schema) (string, bool) { return runes(s.MinLength) }},
+	{"maxLength", func(s *Schema) (string, bool) { return runes(s.MaxLength) }},
+	{"pattern", func(s *Schema) (string, bool) {
+		return s.Pattern, s.Pattern != ""
+	}},
+	{"enum", func(s *Schema) (string, bool) { return values(s.Enum) }},
+}
+
+func float(f *float64) (string, bool) {
+	if f == nil {
+		return "", false
+	}
+	return strconv.FormatFloat(*f, 'g', -1, 64), true
+}
+
+func runes(n *int) (string, bool) {
+	if n == nil {
+		return "", false
+	}
+	return strconv.Itoa(*n) + ",runes", true
+}
+
+// values returns enum values comma separated.
+func values(enum []any) (string, bool) {
+	all := make([]string, len(enum))
+	for i, e := range enum {
+		all[i] = fmt.Sprint(e)
+	}
+	return strings.Join(all, ","), len(enum) > 0
+}
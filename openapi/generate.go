@@ -0,0 +1,269 @@
+package openapi
+
+import (
+	"encoding"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gregoryv/xr"
+)
+
+// NewOperation returns an operation with the given id describing
+// the type of v, a struct or pointer to struct. Fields tagged path,
+// query, header or cookie are parameters, fields tagged form are
+// properties of an application/x-www-form-urlencoded request body
+// and remaining fields, named by their json tag, properties of an
+// application/json request body. Schemas include the validation
+// tags, e.g. minimum, maxLength or enum, of each field. Parameter
+// names are resolved by p as when picking, i.e. with prefixes of
+// nested structs, naming and mappings applied.
+func NewOperation(p *xr.Picker, id string, v any) *Operation {
+	t := structType(v)
+	op := Operation{
+		OperationID: id,
+		Parameters:  parameters(p, v),
+	}
+	content := make(map[string]*MediaType)
+	if form := formSchema(t); form != nil {
+		content["application/x-www-form-urlencoded"] = &MediaType{form}
+	}
+	if body := bodySchema(t); body != nil {
+		content["application/json"] = &MediaType{body}
+	}
+	if len(content) > 0 {
+		op.RequestBody = &RequestBody{Content: content}
+	}
+	return &op
+}
+
+// parameters returns parameters of fields tagged with a location.
+func parameters(p *xr.Picker, v any) []*Parameter {
+	var params []*Parameter
+	for _, f := range p.SourceFields(v, locations...) {
+		params = append(params, &Parameter{
+			Name:     f.Name,
+			In:       f.Kind,
+			Required: f.Kind == "path" || isRequired(f.Field),
+			Style:    f.Field.Tag.Get("style"),
+			Schema:   fieldSchema(f.Field),
+		})
+	}
+	return params
+}
+
+// formSchema returns an object schema of fields tagged form, nil if
+// there are none.
+func formSchema(t reflect.Type) *Schema {
+	return nonEmpty(objectSchema(t, formName))
+}
+
+func formName(f reflect.StructField) (string, bool) {
+	name := f.Tag.Get("form")
+	return name, name != ""
+}
+
+// bodySchema returns an object schema of exported fields without
+// source tags, nil if there are none.
+func bodySchema(t reflect.Type) *Schema {
+	return nonEmpty(objectSchema(t, bodyName))
+}
+
+// objectSchema returns an object schema of the fields of t named by
+// nameOf, fields of embedded structs included.
+func objectSchema(t reflect.Type,
+	nameOf func(reflect.StructField) (string, bool),
+) *Schema {
+	s := Schema{Type: "object"}
+	for _, f := range reflect.VisibleFields(t) {
+		if name, ok := nameOf(f); ok && !f.Anonymous {
+			s.addProperty(name, f)
+		}
+	}
+	return &s
+}
+
+// bodyName returns the JSON name of field f decoded from the body.
+func bodyName(f reflect.StructField) (string, bool) {
+	if !f.IsExported() || hasSource(f.Tag) {
+		return "", false
+	}
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" {
+		name = f.Name
+	}
+	return name, name != "-"
+}
+
+// hasSource returns true if tag reads from other sources than the
+// body.
+func hasSource(tag reflect.StructTag) bool {
+	for _, source := range sources {
+		if _, found := tag.Lookup(source); found {
+			return true
+		}
+	}
+	return false
+}
+
+var sources = []string{
+	"path", "query", "header", "cookie", "form", "request", "part",
+	"body", "ctx", "basicauth", "bearer", "trailer",
+}
+
+func nonEmpty(s *Schema) *Schema {
+	if len(s.Properties) == 0 {
+		return nil
+	}
+	return s
+}
+
+// addProperty adds the schema of field f by name.
+func (s *Schema) addProperty(name string, f reflect.StructField) {
+	if s.Properties == nil {
+		s.Properties = make(map[string]*Schema)
+	}
+	s.Properties[name] = fieldSchema(f)
+	if isRequired(f) {
+		s.Required = append(s.Required, name)
+	}
+}
+
+func isRequired(f reflect.StructField) bool {
+	return f.Tag.Get("required") == "true"
+}
+
+// fieldSchema returns the schema of the type and validation tags of
+// field f.
+func fieldSchema(f reflect.StructField) *Schema {
+	s := typeSchema(f.Type)
+	for _, t := range tagSchemas {
+		if v, found := f.Tag.Lookup(t.tag); found {
+			t.fn(s, v)
+		}
+	}
+	return s
+}
+
+// tagSchemas set schema constraints from field tags.
+var tagSchemas = []struct {
+	tag string
+	fn  func(s *Schema, v string)
+}{
+	{"minimum", func(s *Schema, v string) { s.Minimum = number(v) }},
+	{"maximum", func(s *Schema, v string) { s.Maximum = number(v) }},
+	{"exclusiveMinimum", func(s *Schema, v string) {
+		s.ExclusiveMinimum = exclusiveOf(v)
+	}},
+	{"exclusiveMaximum", func(s *Schema, v string) {
+		s.ExclusiveMaximum = exclusiveOf(v)
+	}},
+	{"minLength", func(s *Schema, v string) { s.MinLength = length(v) }},
+	{"maxLength", func(s *Schema, v string) { s.MaxLength = length(v) }},
+	{"pattern", func(s *Schema, v string) { s.Pattern = v }},
+	{"format", func(s *Schema, v string) { s.Format = v }},
+	{"enum", func(s *Schema, v string) { s.Enum = enum(s.Type, v) }},
+}
+
+func number(v string) *float64 {
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return nil
+	}
+	return &f
+}
+
+// exclusiveOf returns the draft-04 flag, e.g. "true", or limit v.
+func exclusiveOf(v string) *Exclusive {
+	if v == "true" || v == "false" {
+		return &Exclusive{Flag: v == "true"}
+	}
+	return &Exclusive{Limit: number(v)}
+}
+
+// length returns the length of v, e.g. "20" or "20,runes".
+func length(v string) *int {
+	v, _, _ = strings.Cut(v, ",")
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+// enum returns comma separated values of v, as numbers for numeric
+// types.
+func enum(typ, v string) []any {
+	var values []any
+	for _, e := range strings.Split(v, ",") {
+		if f := number(e); f != nil && typ != "string" {
+			values = append(values, *f)
+			continue
+		}
+		values = append(values, e)
+	}
+	return values
+}
+
+// typeSchema returns the schema of t without constraints.
+func typeSchema(t reflect.Type) *Schema {
+	t = indirect(t)
+	if s, found := typeSchemas[t]; found {
+		return &s
+	}
+	switch {
+	case isText(t):
+		return &Schema{Type: "string"}
+	case t.Kind() == reflect.Slice:
+		return &Schema{Type: "array", Items: typeSchema(t.Elem())}
+	case t.Kind() == reflect.Struct:
+		return objectSchema(t, bodyName)
+	}
+	return &Schema{Type: kindTypes[t.Kind()]}
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// isText returns true if t is set from text, i.e. implements
+// encoding.TextUnmarshaler or xr.Setter.
+func isText(t reflect.Type) bool {
+	ptr := reflect.PointerTo(t)
+	return ptr.Implements(textUnmarshaler) || ptr.Implements(setter)
+}
+
+var (
+	textUnmarshaler = reflect.TypeFor[encoding.TextUnmarshaler]()
+	setter          = reflect.TypeFor[xr.Setter]()
+)
+
+// typeSchemas of types encoded as strings
+var typeSchemas = map[reflect.Type]Schema{
+	reflect.TypeFor[time.Time](): {Type: "string", Format: "date-time"},
+	reflect.TypeFor[[]byte]():    {Type: "string", Format: "byte"},
+}
+
+// kindTypes map kinds to schema types
+var kindTypes = map[reflect.Kind]string{
+	reflect.Bool:    "boolean",
+	reflect.Int:     "integer",
+	reflect.Int8:    "integer",
+	reflect.Int16:   "integer",
+	reflect.Int32:   "integer",
+	reflect.Int64:   "integer",
+	reflect.Uint:    "integer",
+	reflect.Uint8:   "integer",
+	reflect.Uint16:  "integer",
+	reflect.Uint32:  "integer",
+	reflect.Uint64:  "integer",
+	reflect.Uintptr: "integer",
+	reflect.Float32: "number",
+	reflect.Float64: "number",
+	reflect.String:  "string",
+	reflect.Map:     "object",
+}
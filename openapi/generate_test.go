@@ -0,0 +1,151 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gregoryv/xr"
+)
+
+func ExampleNewOperation() {
+	type CreateItem struct {
+		Shop  string `path:"shop"`
+		Name  string `json:"name" required:"true" maxLength:"20"`
+		Count int    `json:"count" minimum:"1"`
+	}
+	op := NewOperation(xr.NewPicker(), "createItem", CreateItem{})
+	data, _ := json.MarshalIndent(op, "", " ")
+	fmt.Println(string(data))
+	// output:
+	// {
+	//  "operationId": "createItem",
+	//  "parameters": [
+	//   {
+	//    "name": "shop",
+	//    "in": "path",
+	//    "required": true,
+	//    "schema": {
+	//     "type": "string"
+	//    }
+	//   }
+	//  ],
+	//  "requestBody": {
+	//   "content": {
+	//    "application/json": {
+	//     "schema": {
+	//      "type": "object",
+	//      "properties": {
+	//       "count": {
+	//        "type": "integer",
+	//        "minimum": 1
+	//       },
+	//       "name": {
+	//        "type": "string",
+	//        "maxLength": 20
+	//       }
+	//      },
+	//      "required": [
+	//       "name"
+	//      ]
+	//     }
+	//    }
+	//   }
+	//  }
+	// }
+}
+
+func TestNewOperation(t *testing.T) {
+	type Tag struct {
+		Name string `json:"name"`
+	}
+	type Embedded struct {
+		Note string `form:"note"`
+	}
+	var x struct {
+		Embedded
+		Limit   int               `query:"limit" exclusiveMinimum:"true"`
+		Sort    string            `query:"sort" enum:"asc,desc"`
+		Size    int               `query:"size" enum:"1,2"`
+		Filter  map[string]string `query:"filter"`
+		Session string            `cookie:"session" required:"true"`
+		Host    string            `request:"hostname"`
+		When    *time.Time        `json:"when"`
+		Data    []byte            `json:"data"`
+		Tags    []Tag             `json:"tags"`
+		Color   Color             `json:"color"`
+		Skip    string            `json:"-"`
+		Plain   bool
+		private int
+	}
+	op := NewOperation(xr.NewPicker(), "x", &x)
+	data, _ := json.Marshal(op)
+	got := string(data)
+	exp := `{"operationId":"x","parameters":[` +
+		`{"name":"limit","in":"query","schema":{"type":"integer",` +
+		`"exclusiveMinimum":true}},` +
+		`{"name":"sort","in":"query","schema":{"type":"string",` +
+		`"enum":["asc","desc"]}},` +
+		`{"name":"size","in":"query","schema":{"type":"integer",` +
+		`"enum":[1,2]}},` +
+		`{"name":"filter","in":"query","schema":{"type":"object"}},` +
+		`{"name":"session","in":"cookie","required":true,` +
+		`"schema":{"type":"string"}}],` +
+		`"requestBody":{"content":{"application/json":{"schema":` +
+		`{"type":"object","properties":{"Plain":{"type":"boolean"},` +
+		`"color":{"type":"string"},` +
+		`"data":{"type":"string","format":"byte"},` +
+		`"tags":{"type":"array","items":{"type":"object",` +
+		`"properties":{"name":{"type":"string"}}}},` +
+		`"when":{"type":"string","format":"date-time"}}}},` +
+		`"application/x-www-form-urlencoded":{"schema":` +
+		`{"type":"object","properties":{"note":{"type":"string"}}}}}}}`
+	if got != exp {
+		t.Errorf("\ngot %s\nexp %s", got, exp)
+	}
+}
+
+func TestNewOperation_noBody(t *testing.T) {
+	var x struct {
+		Limit int `query:"limit" minimum:"x" minLength:"y"`
+	}
+	op := NewOperation(xr.NewPicker(), "x", x)
+	s := op.Parameters[0].Schema
+	if op.RequestBody != nil || s.Minimum != nil || s.MinLength != nil {
+		t.Errorf("%+v", op)
+	}
+}
+
+func TestNewOperation_nested(t *testing.T) {
+	type Address struct {
+		Street string `query:""`
+	}
+	var x struct {
+		Address Address `query:"address."`
+		Limit   int     `query:""`
+	}
+	p := xr.NewPicker()
+	p.SetNaming(xr.SnakeCase)
+	var got []string
+	for _, param := range NewOperation(p, "x", x).Parameters {
+		got = append(got, param.Name)
+	}
+	if exp := "[address.street limit]"; fmt.Sprint(got) != exp {
+		t.Errorf("got %v, exp %s", got, exp)
+	}
+}
+
+func TestNewOperation_style(t *testing.T) {
+	var x struct {
+		Tags []string `query:"tags" style:"pipeDelimited"`
+	}
+	op := NewOperation(xr.NewPicker(), "x", x)
+	if got := op.Parameters[0].Style; got != "pipeDelimited" {
+		t.Error(got)
+	}
+}
+
+type Color int
+
+func (c *Color) UnmarshalText(text []byte) error { return nil }
@@ -0,0 +1,195 @@
+// Package openapi binds OpenAPI operations to types picked with
+// package xr.
+//
+// Parameters of an operation are validated at runtime, on top of
+// the field tags, and divergence between the document and struct
+// tags is reported when binding. Only JSON documents are supported.
+//
+// Operations can also be generated from the field tags, see
+// NewOperation.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Load reads an OpenAPI 3 document in JSON format.
+func Load(r io.Reader) (*Document, error) {
+	var doc Document
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("openapi.Load: %w", err)
+	}
+	return &doc, nil
+}
+
+// Document is the subset of an OpenAPI document needed for binding.
+type Document struct {
+	Paths      map[string]*PathItem `json:"paths"`
+	Components struct {
+		Parameters map[string]*Parameter `json:"parameters"`
+	} `json:"components"`
+}
+
+// Operation returns the operation with the given id. Parameters
+// declared on the path item and references to components are
+// resolved.
+func (d *Document) Operation(id string) (*Operation, error) {
+	for _, item := range d.Paths {
+		if op := item.operation(id); op != nil {
+			return d.resolve(item, op)
+		}
+	}
+	return nil, fmt.Errorf("operation %s: not found", id)
+}
+
+// resolve returns a copy of op with all parameters of the path item
+// and references resolved. Parameters of op override those of the
+// path item with the same name and location.
+func (d *Document) resolve(item *PathItem, op *Operation) (
+	*Operation, error,
+) {
+	all := append(append([]*Parameter{}, item.Parameters...), op.Parameters...)
+	res := Operation{OperationID: op.OperationID}
+	for _, p := range all {
+		p, err := d.deref(p)
+		if err != nil {
+			return nil, err
+		}
+		res.Parameters = override(res.Parameters, p)
+	}
+	return &res, nil
+}
+
+// override returns params with p replacing the parameter with the
+// same name and location, or appended if there is none.
+func override(params []*Parameter, p *Parameter) []*Parameter {
+	for i, q := range params {
+		if key(q.In, q.Name) == key(p.In, p.Name) {
+			params[i] = p
+			return params
+		}
+	}
+	return append(params, p)
+}
+
+func (d *Document) deref(p *Parameter) (*Parameter, error) {
+	if p.Ref == "" {
+		return p, nil
+	}
+	name := strings.TrimPrefix(p.Ref, "#/components/parameters/")
+	if found, ok := d.Components.Parameters[name]; ok {
+		return found, nil
+	}
+	return nil, fmt.Errorf("%s: not found", p.Ref)
+}
+
+// PathItem holds the operations of one path.
+type PathItem struct {
+	Parameters []*Parameter `json:"parameters"`
+	Get        *Operation   `json:"get"`
+	Put        *Operation   `json:"put"`
+	Post       *Operation   `json:"post"`
+	Delete     *Operation   `json:"delete"`
+	Patch      *Operation   `json:"patch"`
+	Head       *Operation   `json:"head"`
+	Options    *Operation   `json:"options"`
+}
+
+func (item *PathItem) operation(id string) *Operation {
+	all := []*Operation{
+		item.Get, item.Put, item.Post, item.Delete,
+		item.Patch, item.Head, item.Options,
+	}
+	for _, op := range all {
+		if op != nil && op.OperationID == id {
+			return op
+		}
+	}
+	return nil
+}
+
+// Operation describes the parameters and request body of one
+// operation.
+type Operation struct {
+	OperationID string       `json:"operationId,omitempty"`
+	Parameters  []*Parameter `json:"parameters,omitempty"`
+	RequestBody *RequestBody `json:"requestBody,omitempty"`
+}
+
+// Parameter of an operation.
+type Parameter struct {
+	Ref      string  `json:"$ref,omitempty"`
+	Name     string  `json:"name,omitempty"`
+	In       string  `json:"in,omitempty"` // path, query, header or cookie
+	Required bool    `json:"required,omitempty"`
+	Style    string  `json:"style,omitempty"` // e.g. pipeDelimited
+	Schema   *Schema `json:"schema,omitempty"`
+}
+
+// RequestBody of an operation by content-type.
+type RequestBody struct {
+	Content map[string]*MediaType `json:"content"`
+}
+
+// MediaType describes the content of a request body.
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+// Schema constraints of a parameter value or body.
+type Schema struct {
+	Type      string   `json:"type,omitempty"`
+	Format    string   `json:"format,omitempty"`
+	Minimum   *float64 `json:"minimum,omitempty"`
+	Maximum   *float64 `json:"maximum,omitempty"`
+	MinLength *int     `json:"minLength,omitempty"`
+	MaxLength *int     `json:"maxLength,omitempty"`
+	Pattern   string   `json:"pattern,omitempty"`
+	Enum      []any    `json:"enum,omitempty"`
+
+	// boolean in OpenAPI 3.0, number in 3.1
+	ExclusiveMinimum *Exclusive `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum *Exclusive `json:"exclusiveMaximum,omitempty"`
+
+	// arrays and objects
+	Items      *Schema            `json:"items,omitempty"`
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+}
+
+// Exclusive is either a flag making minimum or maximum exclusive,
+// or the exclusive limit itself.
+type Exclusive struct {
+	Flag  bool
+	Limit *float64
+}
+
+func (e *Exclusive) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &e.Flag); err == nil {
+		return nil
+	}
+	return json.Unmarshal(data, &e.Limit)
+}
+
+func (e *Exclusive) MarshalJSON() ([]byte, error) {
+	if e.Limit != nil {
+		return json.Marshal(*e.Limit)
+	}
+	return json.Marshal(e.Flag)
+}
+
+// arg returns the argument of the exclusive check tag, i.e. the
+// draft-04 flag or the limit, and true if e is set.
+func (e *Exclusive) arg() (string, bool) {
+	switch {
+	case e == nil:
+		return "", false
+	case e.Limit != nil:
+		return float(e.Limit)
+	}
+	return strconv.FormatBool(e.Flag), true
+}
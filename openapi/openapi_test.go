@@ -0,0 +1,236 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gregoryv/xr"
+)
+
+const spec = `{
+  "openapi": "3.0.3",
+  "paths": {
+    "/items/{id}": {
+      "parameters": [{"$ref": "#/components/parameters/id"}],
+      "get": {
+        "operationId": "getItem",
+        "parameters": [
+          {"name": "limit", "in": "query", "required": true,
+           "schema": {"type": "integer", "minimum": 1, "maximum": 100}},
+          {"name": "sort", "in": "query",
+           "schema": {"type": "string", "enum": ["asc", "desc"]}},
+          {"name": "X-Trace", "in": "header",
+           "schema": {"type": "string", "pattern": "^[a-f0-9]+$"}}
+        ]
+      }
+    }
+  },
+  "components": {
+    "parameters": {
+      "id": {"name": "id", "in": "path", "required": true,
+             "schema": {"type": "string", "minLength": 3}}
+    }
+  }
+}`
+
+type GetItem struct {
+	Id    string `path:"id"`
+	Limit int    `query:"limit"`
+	Sort  string `query:"sort"`
+	Trace string `header:"x-trace"`
+}
+
+func Example() {
+	doc, _ := Load(strings.NewReader(spec))
+	op, _ := doc.Operation("getItem")
+	b, err := Bind(xr.NewPicker(), GetItem{}, op)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	var x GetItem
+	r := httptest.NewRequest("GET", "/items/abc?limit=500", http.NoBody)
+	r.SetPathValue("id", "abc")
+	fmt.Println(b.Pick(&x, r))
+	// output:
+	// pick Limit from query[limit]: maximum 100: got 500
+}
+
+func TestBind_divergence(t *testing.T) {
+	doc, _ := Load(strings.NewReader(spec))
+	op, _ := doc.Operation("getItem")
+	var x struct {
+		Id    string `path:"id"`
+		Limit int    `query:"max"`
+	}
+	_, err := Bind(xr.NewPicker(), &x, op)
+	exp := strings.Join([]string{
+		"query[limit]: no field",
+		"query[sort]: no field",
+		"header[X-Trace]: no field",
+		"query[max]: Limit not in operation",
+	}, "\n")
+	if err == nil || err.Error() != exp {
+		t.Errorf("got\n%v\nexp\n%s", err, exp)
+	}
+}
+
+func TestBinding_Pick(t *testing.T) {
+	doc, _ := Load(strings.NewReader(spec))
+	op, _ := doc.Operation("getItem")
+	b, _ := Bind(xr.NewPicker(), GetItem{}, op)
+	cases := map[string]bool{
+		"/?limit=10":             true,
+		"/":                      false,
+		"/?limit=0":              false,
+		"/?limit=ten":            false,
+		"/?limit=1&sort=asc":     true,
+		"/?limit=1&sort=reverse": false,
+	}
+	for u, ok := range cases {
+		var x GetItem
+		r := httptest.NewRequest("GET", u, http.NoBody)
+		r.SetPathValue("id", "abc")
+		err := b.Pick(&x, r)
+		if (err == nil) != ok {
+			t.Errorf("%s: %v", u, err)
+		}
+	}
+}
+
+func TestBinding_Pick_code(t *testing.T) {
+	doc, _ := Load(strings.NewReader(spec))
+	op, _ := doc.Operation("getItem")
+	b, _ := Bind(xr.NewPicker(), GetItem{}, op)
+	cases := map[string]string{
+		"/":          "required []",
+		"/?limit=0":  "minimum [1]",
+		"/?limit=x1": "parse_int [x1]",
+	}
+	for u, exp := range cases {
+		var x GetItem
+		r := httptest.NewRequest("GET", u, http.NoBody)
+		r.SetPathValue("id", "abc")
+		if got := code(b.Pick(&x, r)); got != exp {
+			t.Errorf("%s: got %q, exp %q", u, got, exp)
+		}
+	}
+}
+
+// code returns the code and parameters of a *xr.PickError.
+func code(err error) string {
+	var e *xr.PickError
+	if !errors.As(err, &e) {
+		return fmt.Sprint(err)
+	}
+	return fmt.Sprint(e.Code, " ", e.Params)
+}
+
+func TestBind_naming(t *testing.T) {
+	doc, _ := Load(strings.NewReader(spec))
+	op, _ := doc.Operation("getItem")
+	var x struct {
+		Id    string `path:""`
+		Limit int    `query:""`
+		Sort  string
+		Trace string `header:"x-trace"`
+	}
+	p := xr.NewPicker()
+	p.UseMapping(&x, xr.NewMapping().Field("Sort", `query:"sort"`))
+	if _, err := Bind(p, &x, op); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBinding_Pick_headerAndPath(t *testing.T) {
+	doc, _ := Load(strings.NewReader(spec))
+	op, _ := doc.Operation("getItem")
+	b, _ := Bind(xr.NewPicker(), GetItem{}, op)
+
+	var x GetItem
+	r := httptest.NewRequest("GET", "/?limit=1", http.NoBody)
+	r.SetPathValue("id", "ab")
+	if err := b.Pick(&x, r); err == nil {
+		t.Error("expect minLength error")
+	}
+	r.SetPathValue("id", "abc")
+	r.Header.Set("x-trace", "XYZ")
+	if err := b.Pick(&x, r); err == nil {
+		t.Error("expect pattern error")
+	}
+}
+
+func TestDocument_Operation(t *testing.T) {
+	doc, _ := Load(strings.NewReader(spec))
+	if _, err := doc.Operation("jibberish"); err == nil {
+		t.Error("expect error")
+	}
+	doc.Components.Parameters = nil
+	if _, err := doc.Operation("getItem"); err == nil {
+		t.Error("expect error for broken $ref")
+	}
+	if _, err := Load(strings.NewReader("{")); err == nil {
+		t.Error("expect error")
+	}
+}
+
+func TestBinding_check_exclusive(t *testing.T) {
+	b := Binding{picker: xr.NewPicker()}
+	one := 1.0
+	s30 := &Schema{Type: "number", Minimum: &one}
+	s30.ExclusiveMinimum = &Exclusive{Flag: true}
+	s31 := &Schema{Type: "number"}
+	s31.ExclusiveMaximum = &Exclusive{Limit: &one}
+	if b.check(&Parameter{Schema: s30}, "1", true) == nil {
+		t.Error("3.0 exclusiveMinimum")
+	}
+	if b.check(&Parameter{Schema: s31}, "1", true) == nil {
+		t.Error("3.1 exclusiveMaximum")
+	}
+	if err := b.check(&Parameter{Schema: s31}, "0.5", true); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDocument_Operation_override(t *testing.T) {
+	doc, _ := Load(strings.NewReader(`{"paths": {"/items/{id}": {
+  "parameters": [
+    {"name": "id", "in": "path", "schema": {"type": "string"}},
+    {"name": "x-trace", "in": "header", "schema": {"type": "string"}}
+  ],
+  "get": {"operationId": "getItem", "parameters": [
+    {"name": "id", "in": "path", "schema": {"type": "integer"}},
+    {"name": "X-Trace", "in": "header", "required": true}
+  ]}
+}}}`))
+	op, err := doc.Operation("getItem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(op.Parameters) != 2 {
+		t.Fatal("got", len(op.Parameters), "parameters")
+	}
+	if op.Parameters[0].Schema.Type != "integer" || !op.Parameters[1].Required {
+		t.Error("path item parameters not overridden")
+	}
+}
+
+func TestBinding_Pick_caseInsensitiveQuery(t *testing.T) {
+	doc, _ := Load(strings.NewReader(spec))
+	op, _ := doc.Operation("getItem")
+	p := xr.NewPicker()
+	p.SetCaseInsensitiveQuery(true)
+	b, _ := Bind(p, GetItem{}, op)
+
+	var x GetItem
+	r := httptest.NewRequest("GET", "/?LIMIT=500", http.NoBody)
+	r.SetPathValue("id", "abc")
+	if err := b.Pick(&x, r); err == nil {
+		t.Error("expect maximum error")
+	}
+}
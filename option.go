@@ -0,0 +1,131 @@
+package xr
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// Option configures a picker, see NewPicker.
+type Option func(*Picker)
+
+// WithJSON registers JSONDecoder and JSONEncoder for
+// application/json.
+func WithJSON() Option {
+	return func(p *Picker) {
+		p.Register("application/json", JSONDecoder)
+		p.RegisterEncoder("application/json", JSONEncoder)
+	}
+}
+
+// WithDecoder registers the body decoder for contentType, see
+// Picker.Register.
+func WithDecoder(contentType string, fn func(io.Reader) Decoder) Option {
+	return func(p *Picker) { p.Register(contentType, fn) }
+}
+
+// WithEncoder registers the response encoder for contentType, see
+// Picker.RegisterEncoder.
+func WithEncoder(contentType string, fn func(io.Writer) Encoder) Option {
+	return func(p *Picker) { p.RegisterEncoder(contentType, fn) }
+}
+
+// WithStrictContentType rejects bodies without a decoder, see
+// Picker.SetStrictMediaType.
+func WithStrictContentType() Option {
+	return func(p *Picker) { p.SetStrictMediaType(true) }
+}
+
+// WithMaxBodyBytes limits the size of bodies, see
+// Picker.SetMaxBodyBytes.
+func WithMaxBodyBytes(n int64) Option {
+	return func(p *Picker) { p.SetMaxBodyBytes(n) }
+}
+
+// WithAllErrors joins errors of all fields, see
+// Picker.SetAllErrors.
+func WithAllErrors() Option {
+	return func(p *Picker) { p.SetAllErrors(true) }
+}
+
+// WithCaseInsensitiveQuery matches query parameters regardless of
+// case, see Picker.SetCaseInsensitiveQuery.
+func WithCaseInsensitiveQuery() Option {
+	return func(p *Picker) { p.SetCaseInsensitiveQuery(true) }
+}
+
+// PickOption modifies the destination before picking, see
+// Picker.PickInto.
+type PickOption func(dst any)
+
+// WithDefaults copies prototype, a value or pointer of the same type
+// as the destination, before picking. Slices, maps and pointers are
+// copied so the prototype is never modified. Panics if the types
+// differ.
+func WithDefaults(prototype any) PickOption {
+	src := reflect.Indirect(reflect.ValueOf(prototype))
+	return func(dst any) {
+		obj := reflect.ValueOf(dst).Elem()
+		if obj.Type() != src.Type() {
+			panic(fmt.Sprintf(
+				"WithDefaults: %v not %v", src.Type(), obj.Type(),
+			))
+		}
+		deepCopy(obj, src)
+	}
+}
+
+// deepCopy sets dst to a copy of src not sharing any slices, maps or
+// pointers.
+func deepCopy(dst, src reflect.Value) {
+	dst.Set(src)
+	switch src.Kind() {
+	case reflect.Struct:
+		copyFields(dst, src)
+	case reflect.Slice:
+		copySlice(dst, src)
+	case reflect.Map:
+		copyMap(dst, src)
+	case reflect.Pointer:
+		copyPointer(dst, src)
+	}
+}
+
+func copyFields(dst, src reflect.Value) {
+	for i := 0; i < src.NumField(); i++ {
+		if dst.Field(i).CanSet() {
+			deepCopy(dst.Field(i), src.Field(i))
+		}
+	}
+}
+
+func copySlice(dst, src reflect.Value) {
+	if src.IsNil() {
+		return
+	}
+	dst.Set(reflect.MakeSlice(src.Type(), src.Len(), src.Len()))
+	for i := 0; i < src.Len(); i++ {
+		deepCopy(dst.Index(i), src.Index(i))
+	}
+}
+
+func copyMap(dst, src reflect.Value) {
+	if src.IsNil() {
+		return
+	}
+	dst.Set(reflect.MakeMapWithSize(src.Type(), src.Len()))
+	iter := src.MapRange()
+	for iter.Next() {
+		v := reflect.New(iter.Value().Type()).Elem()
+		deepCopy(v, iter.Value())
+		dst.SetMapIndex(iter.Key(), v)
+	}
+}
+
+func copyPointer(dst, src reflect.Value) {
+	if src.IsNil() {
+		return
+	}
+	dst.Set(reflect.New(src.Elem().Type()))
+	deepCopy(dst.Elem(), src.Elem())
+}
@@ -0,0 +1,97 @@
+package xr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func ExampleNewPicker() {
+	p := NewPicker(WithJSON(), WithAllErrors(), WithMaxBodyBytes(64))
+	var x struct {
+		Name  string `json:"name"`
+		Limit int    `query:"limit" minimum:"1"`
+		Page  int    `query:"page" minimum:"1"`
+	}
+	body := strings.NewReader(`{"name":"John"}`)
+	r := httptest.NewRequest("POST", "/?limit=0&page=0", body)
+	r.Header.Set("content-type", "application/json")
+	fmt.Println(p.Pick(&x, r))
+	fmt.Println(x.Name)
+	// output:
+	// pick Limit from query[limit]: minimum 1: got 0
+	// pick Page from query[page]: minimum 1: got 0
+	// John
+}
+
+func TestNewPicker_options(t *testing.T) {
+	p := NewPicker(
+		WithDecoder("text/plain", JSONDecoder),
+		WithEncoder("application/json", JSONEncoder),
+		WithStrictContentType(),
+	)
+	r := httptest.NewRequest("POST", "/", strings.NewReader("{}"))
+	r.Header.Set("content-type", "application/json")
+	var x struct{}
+	if err := p.Pick(&x, r); !errors.Is(err, ErrUnsupportedMediaType) {
+		t.Error("expect ErrUnsupportedMediaType, got", err)
+	}
+	if _, found := p.decoderFor("text/plain"); !found {
+		t.Error("missing text/plain decoder")
+	}
+	w := httptest.NewRecorder()
+	if err := p.Respond(w, r, 200, x); err != nil {
+		t.Error(err)
+	}
+}
+
+func ExampleWithDefaults() {
+	type Search struct {
+		Query string   `query:"q"`
+		Sort  []string `json:"sort"`
+		Page  struct {
+			Size int `json:"size"`
+		}
+	}
+	var prototype Search
+	prototype.Sort = []string{"name"}
+	prototype.Page.Size = 20
+
+	var x Search
+	r := httptest.NewRequest("GET", "/?q=shoes", http.NoBody)
+	_ = PickInto(&x, r, WithDefaults(prototype))
+	fmt.Printf("%+v", x)
+	// output:
+	// {Query:shoes Sort:[name] Page:{Size:20}}
+}
+
+func TestWithDefaults_deepCopy(t *testing.T) {
+	type T struct {
+		Tags  []string
+		Attrs map[string]*int
+		Next  *T
+	}
+	n := 1
+	prototype := &T{
+		Tags:  []string{"a"},
+		Attrs: map[string]*int{"n": &n},
+		Next:  &T{Tags: []string{"b"}},
+	}
+	var x T
+	WithDefaults(prototype)(&x)
+	x.Tags[0] = "changed"
+	*x.Attrs["n"] = 2
+	x.Next.Tags[0] = "changed"
+	if prototype.Tags[0] != "a" || n != 1 || prototype.Next.Tags[0] != "b" {
+		t.Errorf("prototype modified: %+v", prototype)
+	}
+}
+
+func TestWithDefaults_wrongType(t *testing.T) {
+	defer catchPanic(t)
+	var x struct{ A int }
+	WithDefaults(struct{ B int }{})(&x)
+}
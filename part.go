@@ -0,0 +1,133 @@
+package xr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+)
+
+// pickParts sets fields tagged `part:"NAME"` from the parts of a
+// multipart body. Parts are decoded using the decoder registered for
+// their content-type, except for string and []byte fields which get
+// the content as is. A field of type io.Reader or *multipart.Part
+// gets the part for streaming and must be the last part picked.
+func (p *Picker) pickParts(dst any, r *http.Request) error {
+	fields := p.partFields(dst)
+	if len(fields) == 0 {
+		return nil
+	}
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return NewPickError(destName(dst), "body", err)
+	}
+	return p.readParts(reflect.ValueOf(dst), mr, fields)
+}
+
+// readParts sets the fields, by part name, of obj until a streaming
+// field is set or there are no more parts.
+func (p *Picker) readParts(
+	obj reflect.Value, mr *multipart.Reader, fields map[string]int,
+) error {
+	for len(fields) > 0 {
+		part, err := mr.NextPart()
+		if err != nil {
+			return partsError(obj, err)
+		}
+		stream, err := p.pickNamedPart(obj, fields, part)
+		if stream || err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// partsError returns a *PickError for err unless it's io.EOF.
+func partsError(obj reflect.Value, err error) error {
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return NewPickError(destName(obj.Interface()), "body", err)
+}
+
+// pickNamedPart picks part into the field of the same name, if any,
+// and returns true if the part is to be streamed.
+func (p *Picker) pickNamedPart(
+	obj reflect.Value, fields map[string]int, part *multipart.Part,
+) (bool, error) {
+	i, found := fields[part.FormName()]
+	if !found {
+		return false, nil
+	}
+	delete(fields, part.FormName())
+	return p.pickPart(obj, i, part)
+}
+
+func (p *Picker) pickPart(obj reflect.Value, i int, part *multipart.Part) (
+	bool, error,
+) {
+	v := obj.Elem().Field(i)
+	if v.Type() == readerType || v.Type() == partType {
+		v.Set(reflect.ValueOf(part))
+		return true, nil
+	}
+	if err := p.decodePart(v, part); err != nil {
+		src := fmt.Sprintf("part[%s]", part.FormName())
+		return false, NewPickError(p.field(obj, i).Name, src, err)
+	}
+	return false, nil
+}
+
+var partType = reflect.TypeFor[*multipart.Part]()
+
+// decodePart decodes part into v using the decoder registered for
+// the content-type of part.
+func (p *Picker) decodePart(v reflect.Value, part *multipart.Part) error {
+	if v.Kind() == reflect.String || v.Type() == bytesType {
+		return p.readPart(v, part)
+	}
+	ct := part.Header.Get("content-type")
+	fn, found := p.decoderFor(ct)
+	if !found {
+		return fmt.Errorf("content-type %q: unsupported", ct)
+	}
+	return fn(part).Decode(v.Addr().Interface())
+}
+
+// readPart sets string or []byte v to the content of part.
+func (p *Picker) readPart(v reflect.Value, part *multipart.Part) error {
+	data, err := readAll(part, p.multipartMemory)
+	if err != nil {
+		return err
+	}
+	if v.Kind() == reflect.String {
+		v.SetString(string(data))
+		return nil
+	}
+	v.SetBytes(data)
+	return nil
+}
+
+// partFields returns the indexes of fields tagged part by part name,
+// nil if there are none.
+func (p *Picker) partFields(dst any) map[string]int {
+	obj := reflect.ValueOf(dst)
+	var fields map[string]int
+	for i := 0; i < obj.Elem().NumField(); i++ {
+		if name, found := p.tagName(p.field(obj, i), "part"); found {
+			fields = setIndex(fields, name, i)
+		}
+	}
+	return fields
+}
+
+// setIndex sets fields[name] to i, allocating fields if nil.
+func setIndex(fields map[string]int, name string, i int) map[string]int {
+	if fields == nil {
+		fields = make(map[string]int)
+	}
+	fields[name] = i
+	return fields
+}
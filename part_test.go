@@ -0,0 +1,79 @@
+package xr
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+)
+
+func Example_parts() {
+	var x struct {
+		Meta struct {
+			Title string `json:"title"`
+		} `part:"metadata"`
+		Note string    `part:"note"`
+		File io.Reader `part:"file"`
+	}
+	r := multipartRequest(map[string]string{
+		"metadata": "application/json",
+		"note":     "text/plain",
+		"file":     "application/octet-stream",
+	})
+	_ = Pick(&x, r)
+	data, _ := io.ReadAll(x.File)
+	fmt.Println(x.Meta.Title, x.Note, string(data))
+	// output:
+	// metadata-content note-content file-content
+}
+
+func TestPicker_pickParts_unsupported(t *testing.T) {
+	var x struct {
+		Meta struct{} `part:"metadata"`
+	}
+	r := multipartRequest(map[string]string{"metadata": "text/yaml"})
+	if err := Pick(&x, r); err == nil {
+		t.Error("expect error")
+	}
+}
+
+func TestPicker_pickParts_notMultipart(t *testing.T) {
+	var x struct {
+		Meta struct{} `part:"metadata"`
+	}
+	r := httptest.NewRequest("POST", "/", nil)
+	if err := Pick(&x, r); err == nil {
+		t.Error("expect error")
+	}
+}
+
+// multipartRequest returns a request with one part per name and
+// content-type. JSON parts have a title, others the content
+// NAME-content.
+func multipartRequest(parts map[string]string) *http.Request {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for _, name := range []string{"metadata", "note", "file"} {
+		ct, found := parts[name]
+		if !found {
+			continue
+		}
+		h := textproto.MIMEHeader{}
+		h.Set("Content-Disposition", `form-data; name="`+name+`"`)
+		h.Set("Content-Type", ct)
+		pw, _ := w.CreatePart(h)
+		content := name + "-content"
+		if ct == "application/json" {
+			content = `{"title":"` + content + `"}`
+		}
+		_, _ = pw.Write([]byte(content))
+	}
+	_ = w.Close()
+	r := httptest.NewRequest("POST", "/", &body)
+	r.Header.Set("content-type", w.FormDataContentType())
+	return r
+}
@@ -0,0 +1,67 @@
+package xr
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// CheckPattern returns an error for each path tag of struct v
+// without a wildcard in the http.ServeMux pattern, and for each
+// wildcard without a path tag. Use it when registering handlers to
+// fail fast instead of picking empty path values.
+func (p *Picker) CheckPattern(pattern string, v any) error {
+	wildcards := patternWildcards(pattern)
+	tags := p.pathTags(v)
+	var errs []error
+	for _, name := range missing(tags, wildcards) {
+		errs = append(errs, fmt.Errorf(
+			"path[%s]: no wildcard in pattern %q", name, pattern,
+		))
+	}
+	for _, name := range missing(wildcards, tags) {
+		errs = append(errs, fmt.Errorf(
+			"pattern %q: no field tagged path:%q", pattern, name,
+		))
+	}
+	return errors.Join(errs...)
+}
+
+// patternWildcards returns the wildcard names of a http.ServeMux
+// pattern, e.g. "GET /items/{id}/{rest...}" has id and rest.
+func patternWildcards(pattern string) map[string]bool {
+	names := make(map[string]bool)
+	path := pattern[max(0, strings.Index(pattern, "/")):]
+	for _, seg := range strings.Split(path, "/") {
+		name, found := strings.CutPrefix(seg, "{")
+		if !found || name == "$}" {
+			continue
+		}
+		name = strings.TrimSuffix(name, "}")
+		names[strings.TrimSuffix(name, "...")] = true
+	}
+	return names
+}
+
+// pathTags returns names of path tags of struct, or pointer to
+// struct, v, including those of nested structs.
+func (p *Picker) pathTags(v any) map[string]bool {
+	names := make(map[string]bool)
+	for _, f := range p.SourceFields(v, "path") {
+		names[f.Name] = true
+	}
+	return names
+}
+
+// missing returns sorted names in a but not in b.
+func missing(a, b map[string]bool) []string {
+	var names []string
+	for name := range a {
+		if !b[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
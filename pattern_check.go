@@ -0,0 +1,36 @@
+package xr
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+)
+
+// checkPattern returns an error if non-empty string field does not
+// match the regular expression, e.g. `pattern:"^[a-z0-9-]+$"`.
+func (p *Picker) checkPattern(field reflect.Value, expr string) error {
+	if field.Kind() != reflect.String {
+		return fmt.Errorf("pattern %v: unsupported", field.Kind())
+	}
+	re, err := p.compile(expr)
+	if err != nil {
+		return fmt.Errorf("pattern %s: %w", expr, err)
+	}
+	if v := field.String(); v != "" && !re.MatchString(v) {
+		return fmt.Errorf("pattern %s: %q does not match", expr, v)
+	}
+	return nil
+}
+
+// compile returns the regular expression compiled once.
+func (p *Picker) compile(expr string) (*regexp.Regexp, error) {
+	if re, found := p.patterns.Load(expr); found {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	p.patterns.Store(expr, re)
+	return re, nil
+}
@@ -0,0 +1,38 @@
+package xr
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExamplePick_pattern() {
+	var x struct {
+		S string `path:"s" pattern:"^[a-z0-9-]+$"`
+	}
+	r := httptest.NewRequest("GET", "/", nil)
+	r.SetPathValue("s", "Hello World")
+	fmt.Println(Pick(&x, r))
+	// output:
+	// pick S from path[s]: pattern ^[a-z0-9-]+$: "Hello World" does not match
+}
+
+func TestPick_pattern(t *testing.T) {
+	cases := []struct {
+		tag   string
+		value string
+		ok    bool
+	}{
+		{`query:"n" pattern:"^[a-z]+$"`, "abc", true},
+		{`query:"n" pattern:"^[a-z]+$"`, "", true},
+		{`query:"n" pattern:"^[a-z]+$"`, "ab1", false},
+		{`query:"n" pattern:"(["`, "abc", false},
+	}
+	p := NewPicker()
+	for _, c := range cases {
+		err := pickTagged(p, c.tag, c.value)
+		if (err == nil) != c.ok {
+			t.Error(c.tag, c.value, err)
+		}
+	}
+}
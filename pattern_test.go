@@ -0,0 +1,52 @@
+package xr
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleCheckPattern() {
+	type ItemRead struct {
+		Id    string `path:"id"`
+		Owner string `path:"owner"`
+	}
+	err := CheckPattern("GET /items/{id}/{rest...}", ItemRead{})
+	fmt.Println(err)
+	// output:
+	// path[owner]: no wildcard in pattern "GET /items/{id}/{rest...}"
+	// pattern "GET /items/{id}/{rest...}": no field tagged path:"rest"
+}
+
+func TestCheckPattern(t *testing.T) {
+	var x struct {
+		Id     string `path:"id"`
+		UserID string `path:""`
+		Page   int    `query:"page"`
+	}
+	ok := []string{
+		"/users/{userID}/items/{id}",
+		"GET example.com/users/{userID}/items/{id}/{$}",
+		"/users/{userID}/{id...}",
+	}
+	for _, pattern := range ok {
+		if err := CheckPattern(pattern, &x); err != nil {
+			t.Error(err)
+		}
+	}
+	if err := CheckPattern("/users/{userID}", &x); err == nil {
+		t.Error("expect error for missing wildcard")
+	}
+}
+
+func TestCheckPattern_embedded(t *testing.T) {
+	type Owner struct {
+		Owner string `path:"owner"`
+	}
+	var x struct {
+		Owner
+		Id string `path:"id"`
+	}
+	if err := CheckPattern("/{owner}/items/{id}", &x); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,50 @@
+package xr
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// SetPercentScale sets the value of 100%, used by field tag
+// `unit:"percent"`. Default is 1 so "75%" is picked as 0.75, use 100
+// to pick it as 75.
+func (p *Picker) SetPercentScale(v float64) {
+	p.percentScale = v
+}
+
+// convertUnit converts values of float fields tagged with
+// `unit:"percent"`. The percent sign is optional and values must be
+// within 0 and 100%.
+func (p *Picker) convertUnit(field reflect.StructField, v string) (
+	string, error,
+) {
+	unit, found := field.Tag.Lookup("unit")
+	switch {
+	case !found:
+		return v, nil
+	case unit != "percent":
+		return "", fmt.Errorf("unit %s: unknown", unit)
+	case !isFloat(field.Type.Kind()):
+		return "", fmt.Errorf("unit %s %v: unsupported", unit, field.Type)
+	}
+	return p.percent(v)
+}
+
+func isFloat(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
+
+// percent returns v, e.g. "75%", scaled using the percent scale.
+func (p *Picker) percent(v string) (string, error) {
+	v = strings.TrimSpace(strings.TrimSuffix(v, "%"))
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return "", err
+	}
+	if f < 0 || f > 100 {
+		return "", fmt.Errorf("%v%% out of range", f)
+	}
+	return strconv.FormatFloat(f/100*p.percentScale, 'g', -1, 64), nil
+}
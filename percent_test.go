@@ -0,0 +1,65 @@
+package xr
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func ExamplePicker_SetPercentScale() {
+	var x struct {
+		Discount float64 `query:"discount" unit:"percent"`
+	}
+	q := url.Values{"discount": []string{"75%"}}
+	r := httptest.NewRequest("GET", "/?"+q.Encode(), http.NoBody)
+	_ = Pick(&x, r)
+	fmt.Println(x.Discount)
+
+	p := NewPicker()
+	p.SetPercentScale(100)
+	_ = p.Pick(&x, r)
+	fmt.Println(x.Discount)
+	// output:
+	// 0.75
+	// 75
+}
+
+func TestPicker_convertUnit(t *testing.T) {
+	cases := map[string]bool{
+		"12.5%":   true,
+		"12.5":    true,
+		"0%":      true,
+		"100 %":   true,
+		"101%":    false,
+		"-1%":     false,
+		"a lot %": false,
+	}
+	for v, ok := range cases {
+		var x struct {
+			F float32 `query:"f" unit:"percent"`
+		}
+		q := url.Values{"f": []string{v}}
+		r := httptest.NewRequest("GET", "/?"+q.Encode(), http.NoBody)
+		if err := Pick(&x, r); (err == nil) != ok {
+			t.Errorf("%q: %v", v, err)
+		}
+	}
+}
+
+func TestPicker_convertUnit_unsupported(t *testing.T) {
+	r := httptest.NewRequest("GET", "/?v=1", http.NoBody)
+	var x struct {
+		V float64 `query:"v" unit:"meter"`
+	}
+	if err := Pick(&x, r); err == nil {
+		t.Error("expect error for unknown unit")
+	}
+	var y struct {
+		V string `query:"v" unit:"percent"`
+	}
+	if err := Pick(&y, r); err == nil {
+		t.Error("expect error for string field")
+	}
+}
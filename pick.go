@@ -10,20 +10,49 @@
 package xr
 
 import (
+	"bytes"
+	"encoding"
 	"errors"
 	"fmt"
 	"io"
+	"log"
+	"mime"
 	"net/http"
+	"net/netip"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 )
 
-// NewPicker returns a picker with no content-type decoders.
-func NewPicker() *Picker {
+// NewPicker returns a picker configured by opts, e.g.
+//
+//	p := NewPicker(WithJSON(), WithMaxBodyBytes(1<<20))
+//
+// Without options it has no content-type decoders or encoders.
+func NewPicker(opts ...Option) *Picker {
 	p := Picker{
-		registry: make(map[string]func(io.Reader) Decoder),
-		setters:  make(map[string]setfn),
+		registry:    make(map[string]func(io.Reader) Decoder),
+		encoders:    make(map[string]func(io.Writer) Encoder),
+		setters:     make(map[reflect.Type]setfn),
+		typeNames:   make(map[string]setfn),
+		formats:     make(map[string]func(string) error),
+		normalizers: make(map[string]func(string) string),
+		validators:  make(map[reflect.Type]func(reflect.Value) error),
+		rules:       make(map[string]func(any, string) error),
+		enums:       make(map[string]map[string]int64),
+		mappings:    make(map[reflect.Type]map[string]reflect.StructTag),
+		unique:      make(map[string]bool),
+		contextKeys: make(map[string]any),
+		locales: map[string]*numberLocale{
+			"en": numberFormat('.', ","),
+			"de": numberFormat(',', ".'"),
+			"fr": numberFormat(',', " \u00a0\u202f"),
+			"sv": numberFormat(',', " \u00a0"),
+		},
+		generators: map[string]func() string{
+			"uuid": newUUID,
+		},
 		kindSetters: map[reflect.Kind]setfn{
 			reflect.String: setStringField,
 
@@ -35,150 +64,743 @@ func NewPicker() *Picker {
 			reflect.Int32: setInt32Field,
 			reflect.Int64: setInt64Field,
 
-			reflect.Uint8:  setUint8Field,
-			reflect.Uint16: setUint16Field,
-			reflect.Uint32: setUint32Field,
-			reflect.Uint64: setUint64Field,
+			reflect.Uint:    setUintField,
+			reflect.Uintptr: setUintField,
+			reflect.Uint8:   setUint8Field,
+			reflect.Uint16:  setUint16Field,
+			reflect.Uint32:  setUint32Field,
+			reflect.Uint64:  setUint64Field,
 
 			reflect.Float32: setFloat32Field,
 			reflect.Float64: setFloat64Field,
 
 			reflect.Complex64:  setComplex64Field,
 			reflect.Complex128: setComplex128,
+
+			reflect.Array: setByteArrayField,
 		},
 	}
+	p.readers = map[string]valueReader{
+		"form":    p.readForm,
+		"request": p.readMeta,
+	}
+	for source, fn := range valueReaders {
+		p.readers[source] = fn
+	}
+	p.parseUserAgent = ParseUserAgent
+	p.naming = LowerCamelCase
+	p.multipartMemory = DefaultMultipartMemory
+	p.percentScale = 1
+	p.errorWriter = WriteJSONError
+	p.setters[reflect.TypeFor[UserAgent]()] = p.setUserAgent
+	p.converters = []converter{
+		p.checkUTF8,
+		p.normalize,
+		transform,
+		decodeValue,
+		convertTime,
+		p.localize,
+		p.convertUnit,
+	}
+	p.checks = []check{
+		{"notBlank", checkNotBlank},
+		{"format", p.checkFormat},
+		{"minLength", p.checkMinLength},
+		{"maxLength", p.checkMaxLength},
+		{"pattern", p.checkPattern},
+		{"enum", checkEnum},
+		{"minimum", checkMinimum},
+		{"maximum", checkMaximum},
+		{"exclusiveMinimum", checkExclusiveMinimum},
+		{"exclusiveMaximum", checkExclusiveMaximum},
+		{"multipleOf", checkMultipleOf},
+		{"validate", p.checkRules},
+	}
+	for _, opt := range opts {
+		opt(&p)
+	}
 	return &p
 }
 
+// Picker picks values of requests into structs. Register and Use
+// methods, and SetGenerator, are safe to call while picking. Other
+// Set methods configure the picker and must be called before it's
+// used.
 type Picker struct {
+	// mu guards registered decoders, encoders, setters, formats,
+	// validators, rules, enums, mappings, locales, normalizers,
+	// generators and context keys
+	mu sync.RWMutex
+
+	readers     map[string]valueReader
 	registry    map[string]func(io.Reader) Decoder
-	setters     map[string]setfn
+	encoders    map[string]func(io.Writer) Encoder
+	setters     map[reflect.Type]setfn
+	typeNames   map[string]setfn // deprecated, see UseSetter
 	kindSetters map[reflect.Kind]setfn
+	formats     map[string]func(string) error
+	normalizers map[string]func(string) string
+	generators  map[string]func() string
+	validators  map[reflect.Type]func(reflect.Value) error
+	rules       map[string]func(any, string) error
+	enums       map[string]map[string]int64
+	mappings    map[reflect.Type]map[string]reflect.StructTag
+	contextKeys map[string]any
+
+	// encoderOrder is the order encoders are registered in, the
+	// first is used if the request accepts any media type
+	encoderOrder []string
+
+	// locales normalize numbers, see SetLocale
+	locales map[string]*numberLocale
+	locale  string
+
+	// value of 100%
+	percentScale float64
+
+	// unique source kinds, e.g. header, reject ambiguous values
+	unique map[string]bool
+
+	// converters are applied in order on values before they're set
+	converters []converter
+
+	// checks are applied in order on each field after it's set
+	checks []check
+
+	// tagChecks caches checks by field tag, see checksOf
+	tagChecks sync.Map
+
+	// plans caches how fields are picked by struct type, see planOf
+	plans sync.Map
+
+	// compiled regular expressions of pattern tags
+	patterns sync.Map
+
+	// trailerTypes caches if types have fields tagged trailer
+	trailerTypes sync.Map
+
+	parseUserAgent func(string) UserAgent
+
+	// called for values picked into deprecated fields
+	deprecationHook func(*http.Request, Deprecation)
+
+	// errorWriter writes errors returned to handlers
+	errorWriter ErrorWriter
+	translator  Translator
+	errorLog    *log.Logger
+
+	// naming converts field names for tags with empty names
+	naming func(string) string
+
+	// max bytes of multipart forms kept in memory
+	multipartMemory int64
+
+	// max bytes of bodies, 0 for no limit
+	maxBodyBytes int64
+
+	// forwarding headers are only honored from trusted proxies
+	trustedProxies []netip.Prefix
+
+	// validUTF8 rejects values with invalid UTF-8
+	validUTF8 bool
+
+	// runeLength makes minLength and maxLength count runes
+	runeLength bool
+
+	// allErrors picks all fields even if some fail
+	allErrors bool
+
+	// strictBody rejects bodies for methods that cannot have one
+	strictBody bool
+
+	// strictMediaType rejects bodies without a decoder
+	strictMediaType bool
+
+	// zeroFirst and noOverwrite control existing destination values
+	zeroFirst   bool
+	noOverwrite bool
 }
 
-// Register body decoder based on content-type string.
+// Register body decoder based on content-type string. Decoders
+// registered for a media type, e.g. application/json, are used for
+// any parameters, e.g. "application/json; charset=utf-8", unless one
+// is registered for the exact content-type. Safe to call while
+// picking, e.g. when plugins are loaded after the server has started.
 func (p *Picker) Register(contentType string, fn func(io.Reader) Decoder) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	p.registry[contentType] = fn
 }
 
-// UseSetter typ should be "package.Type"
+// UseSetter typ should be "package.Type". Safe to call while
+// picking.
+//
+// Deprecated: type names are ambiguous and not checked by the
+// compiler, use UseTypeSetter or UseSetterFor.
 func (p *Picker) UseSetter(typ string, fn setfn) {
-	if _, found := p.setters[typ]; found {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, found := p.typeNames[typ]; found {
 		panic(fmt.Sprintf("UseSetter(%q): already exists", typ))
 	}
-	p.setters[typ] = fn
+	p.typeNames[typ] = fn
+}
+
+// UseTypeSetter registers fn setting fields of type t, e.g.
+//
+//	p.UseTypeSetter(reflect.TypeFor[Color](), setColor)
+//
+// Safe to call while picking. Panics if a setter for t already
+// exists.
+func (p *Picker) UseTypeSetter(t reflect.Type,
+	fn func(field reflect.Value, v string) error,
+) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, found := p.setters[t]; found {
+		panic(fmt.Sprintf("UseTypeSetter(%v): already exists", t))
+	}
+	p.setters[t] = fn
+}
+
+// lookup returns m[key] holding the read lock of p.mu, for maps
+// written by Register methods while picking.
+func lookup[K comparable, V any](p *Picker, m map[K]V, key K) (V, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	v, found := m[key]
+	return v, found
+}
+
+// decoder returns the decoder registered for contentType.
+func (p *Picker) decoder(contentType string) (func(io.Reader) Decoder, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	fn, found := p.registry[contentType]
+	return fn, found
+}
+
+// setter returns the set func registered for t, by type or name.
+func (p *Picker) setter(t reflect.Type) (setfn, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if fn, found := p.setters[t]; found {
+		return fn, true
+	}
+	fn, found := p.typeNames[t.String()]
+	return fn, found
+}
+
+// kindSetter returns the set func of kind.
+func (p *Picker) kindSetter(kind reflect.Kind) (setfn, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	fn, found := p.kindSetters[kind]
+	return fn, found
+}
+
+// SetAllErrors controls if Pick continues with the remaining fields
+// when one fails, returning all errors joined. By default Pick stops
+// at the first error.
+func (p *Picker) SetAllErrors(v bool) {
+	p.allErrors = v
+}
+
+// SetStrictBody controls if a GET, HEAD or DELETE request with a
+// non-empty body results in ErrBodyNotAllowed. By default such
+// bodies are silently ignored.
+func (p *Picker) SetStrictBody(v bool) {
+	p.strictBody = v
 }
 
 // Pick the given request into any struct type. Panics if dst is not a pointer.
 func (p *Picker) Pick(dst any, r *http.Request) error {
+	return p.PickInto(dst, r)
+}
+
+// PickInto is like Pick with options for this call only, e.g.
+// [WithDefaults].
+func (p *Picker) PickInto(dst any, r *http.Request, opts ...PickOption) error {
 	if t := reflect.TypeOf(dst); t.Kind() != reflect.Ptr {
-		panic("Pick(dst, r): dst must be a pointer")
+		panic(misuse("Pick(dst, r): dst must be a pointer"))
+	}
+
+	defer parseQuery(r)()
+	p.prepare(dst)
+	for _, opt := range opts {
+		opt(dst)
 	}
 
 	// decide for input format
 	if err := p.decodeBody(dst, r); err != nil {
+		return NewPickError(destName(dst), "body", err)
+	}
+	if err := p.pickParts(dst, r); err != nil {
 		return err
 	}
-
 	return p.pickFields(dst, r)
 }
 
 func (p *Picker) pickFields(dst any, r *http.Request) error {
-	obj := reflect.ValueOf(dst)
-	for i := 0; i < obj.Elem().NumField(); i++ {
-		field := obj.Elem().Type().Field(i)
-		tag := field.Tag
+	p.drainForTrailer(dst, r)
+	if err := p.pickStruct(reflect.ValueOf(dst), r, nil); err != nil {
+		return err
+	}
+	return validateDest(dst, r)
+}
 
-		val, source, err := readValue(r, tag)
-		if errors.Is(err, errTagNotFound) {
+// pickStruct picks all fields of the struct obj points to. Names of
+// source tags are prefixed by source, see pickNested.
+func (p *Picker) pickStruct(obj reflect.Value, r *http.Request,
+	prefix map[string]string,
+) error {
+	var errs []error
+	for i := 0; i < obj.Elem().NumField(); i++ {
+		err := withMessage(p.field(obj, i), p.pickField(obj, i, r, prefix))
+		if err == nil {
 			continue
 		}
-
-		if !field.IsExported() {
-			panic(fmt.Sprintf("%v: private", field.Name))
-		}
-		if err := p.set(obj, i, val); err != nil {
-			return &PickError{
-				Dest:   obj.Elem().Type().Field(i).Name,
-				Source: source,
-				Cause:  err,
-			}
+		if !p.allErrors {
+			return err
 		}
+		errs = append(errs, err)
 	}
-	return nil
+	return errors.Join(errs...)
+}
+
+func (p *Picker) pickField(obj reflect.Value, i int, r *http.Request,
+	prefix map[string]string,
+) error {
+	plan := p.planOf(obj.Elem().Type())[i]
+	switch {
+	case p.keep(obj, i):
+		return nil
+
+	case plan.capture != "":
+		return p.capture(obj, i, r, plan.capture, plan.Tag.Get(plan.capture))
+
+	case plan.files:
+		p.pickFiles(obj, i, r)
+		return nil
+
+	case plan.deepObject:
+		return p.pickDeepObject(obj, i, r)
+	}
+	return p.pickSingle(obj, i, r, prefix)
+}
+
+// pickSingle picks field i of obj from the request context, as a
+// nested struct or from a source value.
+func (p *Picker) pickSingle(obj reflect.Value, i int, r *http.Request,
+	prefix map[string]string,
+) error {
+	plan := p.planOf(obj.Elem().Type())[i]
+	if isContextField(plan.StructField) {
+		return p.pickContext(obj, i, r)
+	}
+	if err := p.checkAuth(plan, r); err != nil {
+		return err
+	}
+	if p.isNested(plan.StructField) {
+		return p.pickNested(obj, i, r, prefix)
+	}
+	return p.pickValue(obj, i, r, prefix)
+}
+
+func (p *Picker) pickValue(obj reflect.Value, i int, r *http.Request,
+	prefix map[string]string,
+) error {
+	plan := p.planOf(obj.Elem().Type())[i]
+	field := plan.StructField
+	v, err := p.readValue(r, plan, prefix)
+	switch {
+	case errors.Is(err, errTagNotFound):
+		// value, if any, is decoded from the body
+		return p.validate(obj, i, bodySource)
+
+	case !field.IsExported():
+		panic(misuse("%v: private", field.Name))
+
+	case errors.Is(err, errValueNotFound):
+		return p.pickMissing(obj, i, v.source())
+	}
+	p.deprecated(r, field, v)
+	return p.pickPresent(obj, i, p.segments(r, field, v))
+}
+
+// pickPresent sets field i of obj to the first of the read values.
+func (p *Picker) pickPresent(obj reflect.Value, i int, v value) error {
+	if isExistsFlag(p.field(obj, i)) {
+		return p.setValue(obj, i, "true", v.source())
+	}
+	if p.isSlice(p.field(obj, i)) {
+		return p.pickSlice(obj, i, v)
+	}
+	if err := p.checkUnique(p.field(obj, i), v); err != nil {
+		return NewPickError(p.field(obj, i).Name, v.source().String(), err)
+	}
+	return p.setValue(obj, i, v.all[0], v.source())
+}
+
+// setValue sets and validates field i of obj.
+func (p *Picker) setValue(obj reflect.Value, i int, val string,
+	src source,
+) error {
+	if err := p.set(obj, i, val); err != nil {
+		return NewPickError(p.field(obj, i).Name, src.String(), err)
+	}
+	return p.validate(obj, i, src)
 }
 
 func (p *Picker) decodeBody(dst any, r *http.Request) error {
 	switch r.Method {
 	case "GET", "HEAD", "DELETE":
 		// cannot have a body for decoding
-		return nil
+		return p.checkNoBody(r)
 
 	default:
-		ct := r.Header.Get("content-type")
-		return p.newDecoder(ct, r.Body).Decode(dst)
+		return p.readBody(dst, r)
+	}
+}
+
+// readBody of r, limited by SetMaxBodyBytes, into dst unless it's
+// streamed by a field tagged `body:""`.
+func (p *Picker) readBody(dst any, r *http.Request) error {
+	if err := p.limitBody(r); err != nil {
+		return err
+	}
+	streamed, err := p.readRawBody(dst, r)
+	if err != nil || streamed {
+		return err
+	}
+	return p.decodeKept(dst, r)
+}
+
+// checkNoBody returns ErrBodyNotAllowed in strict body mode if r has
+// a body.
+func (p *Picker) checkNoBody(r *http.Request) error {
+	if p.strictBody && hasBody(r) {
+		return fmt.Errorf("%s: %w", r.Method, ErrBodyNotAllowed)
+	}
+	return nil
+}
+
+// decode body of r into dst using a decoder registered for the
+// content-type.
+func (p *Picker) decode(dst any, r *http.Request) error {
+	ct := r.Header.Get("content-type")
+	if isMultipart(ct) {
+		return p.decodeMultipart(dst, r)
+	}
+	if isURLEncoded(ct) {
+		return decodeURLEncoded(r)
+	}
+	if err := p.checkMediaType(ct, r); err != nil {
+		return err
 	}
+	return p.decodeSeen(dst, r, ct)
+}
+
+// decodeSeen decodes the body of r into dst, keeping what is read
+// for describing errors. The pooled buffer is detached before it's
+// returned to the pool as dst may keep a reader of the body, e.g.
+// fields tagged `body:"binary"`.
+func (p *Picker) decodeSeen(dst any, r *http.Request, ct string) error {
+	seen := getBuffer()
+	defer putBuffer(seen)
+	tee := &detachableWriter{w: seen}
+	err := p.newDecoder(ct, io.TeeReader(r.Body, tee)).Decode(dst)
+	tee.detach()
+	if err != nil {
+		return fmt.Errorf("%s: %w", ct, newBodyError(err, seen.Bytes()))
+	}
+	return nil
+}
+
+// SetStrictMediaType controls if a body with a content-type without
+// a registered decoder results in ErrUnsupportedMediaType. By
+// default such bodies are silently ignored.
+func (p *Picker) SetStrictMediaType(v bool) {
+	p.strictMediaType = v
+}
+
+// ErrUnsupportedMediaType is returned in strict media type mode when
+// there is no decoder for the content-type of a body.
+var ErrUnsupportedMediaType = errors.New("unsupported media type")
+
+// checkMediaType returns ErrUnsupportedMediaType in strict media type
+// mode if r has a body without a registered decoder.
+func (p *Picker) checkMediaType(ct string, r *http.Request) error {
+	if !p.strictMediaType || !hasBody(r) {
+		return nil
+	}
+	if _, found := p.decoderFor(ct); !found {
+		return fmt.Errorf("%w: %q", ErrUnsupportedMediaType, ct)
+	}
+	return nil
+}
+
+// destName returns the type name of dst, or its kind if unnamed.
+func destName(dst any) string {
+	t := reflect.TypeOf(dst).Elem()
+	if t.Name() == "" {
+		return t.Kind().String()
+	}
+	return t.Name()
+}
+
+// ErrBodyNotAllowed is returned in strict body mode when a request
+// method that cannot have a body has one.
+var ErrBodyNotAllowed = errors.New("body not allowed")
+
+// hasBody returns true if r has a non-empty body. If the length is
+// unknown the first byte is read and put back.
+func hasBody(r *http.Request) bool {
+	if r.Body == nil || r.Body == http.NoBody {
+		return false
+	}
+	if r.ContentLength >= 0 {
+		return r.ContentLength > 0
+	}
+	var b [1]byte
+	n, _ := io.ReadFull(r.Body, b[:])
+	r.Body = readCloser{
+		Reader: io.MultiReader(bytes.NewReader(b[:n]), r.Body),
+		Closer: r.Body,
+	}
+	return n > 0
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
 }
 
 func (p *Picker) newDecoder(v string, r io.Reader) Decoder {
-	if d, found := p.registry[v]; found {
+	if d, found := p.decoderFor(v); found {
 		return d(r)
 	}
 	return noop
 }
 
-func readValue(r *http.Request, tag reflect.StructTag) (string, string, error) {
-	for source, fn := range valueReaders {
-		if v := tag.Get(source); v != "" {
-			return fn(r, v), fmt.Sprintf("%s[%s]", source, v), nil
-		}
+// decoderFor returns the decoder registered for the content-type
+// ct, or its media type.
+func (p *Picker) decoderFor(ct string) (func(io.Reader) Decoder, bool) {
+	if d, found := p.decoder(ct); found {
+		return d, true
 	}
-	return "", "", errTagNotFound
+	mediaType, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return nil, false
+	}
+	return p.decoder(mediaType)
+}
+
+func (p *Picker) readValue(r *http.Request, plan fieldPlan,
+	prefix map[string]string,
+) (value, error) {
+	if plan.source == "" {
+		return value{}, errTagNotFound
+	}
+	name, _ := p.tagName(plan.StructField, plan.source)
+	name = prefix[plan.source] + name
+	v := value{
+		all: p.readers[plan.source](r, name), kind: plan.source, name: name,
+	}
+	return v, present(len(v.all) > 0)
+}
+
+// readerKinds are the sources of readers in the order they are
+// looked up in field tags.
+var readerKinds = []string{
+	"path", "query", "header", "cookie", "form", "request", "basicauth",
+	"bearer", "trailer",
+}
+
+// value read from a request
+type value struct {
+	// all values, empty if missing
+	all []string
+
+	// kind of source, e.g. query, and the name within it
+	kind string
+	name string
+}
+
+// source returns the source of v.
+func (v value) source() source {
+	return source{kind: v.kind, name: v.name}
+}
+
+// source of a value, e.g. kind query and name id. It's formatted
+// only when needed, e.g. for errors.
+type source struct {
+	kind, name string
 }
 
-var errTagNotFound = errors.New("tag not found")
+// bodySource is the source of values decoded from the body.
+var bodySource = source{kind: "body"}
+
+// String returns the formatted source, e.g. query[id] or body.
+func (s source) String() string {
+	if s.name == "" {
+		return s.kind
+	}
+	return s.kind + "[" + s.name + "]"
+}
+
+func present(found bool) error {
+	if !found {
+		return errValueNotFound
+	}
+	return nil
+}
+
+var (
+	errTagNotFound   = errors.New("tag not found")
+	errValueNotFound = errors.New("value not found")
+)
 
 // valueReaders map how field tags are read from a given request
 var valueReaders = map[string]valueReader{
-	"path": func(r *http.Request, name string) string {
-		return r.PathValue(name)
+	"path": func(r *http.Request, name string) []string {
+		return nonEmpty(r.PathValue(name))
 	},
-	"query": func(r *http.Request, name string) string {
-		return r.URL.Query().Get(name)
-	},
-	"header": func(r *http.Request, name string) string {
-		return r.Header.Get(name)
-	},
-	"form": func(r *http.Request, name string) string {
-		return r.FormValue(name)
+	"query": readQuery,
+	"header": func(r *http.Request, name string) []string {
+		return r.Header.Values(name)
 	},
+	"cookie":    readCookie,
+	"basicauth": readBasicAuth,
+	"bearer":    readBearer,
+	"trailer":   readTrailer,
+}
+
+// readCookie returns values of all cookies with the given name.
+func readCookie(r *http.Request, name string) []string {
+	var values []string
+	for _, c := range r.Cookies() {
+		if c.Name == name {
+			values = append(values, c.Value)
+		}
+	}
+	return values
+}
+
+// nonEmpty returns v as the only value, or none if empty.
+func nonEmpty(v string) []string {
+	if v == "" {
+		return nil
+	}
+	return []string{v}
 }
 
 type (
-	valueReader func(*http.Request, string) string
+	// valueReader returns all values of a name, none if missing
+	valueReader func(*http.Request, string) []string
 	setfn       func(field reflect.Value, v string) error
+
+	// converter returns v modified before it's set on field
+	converter func(field reflect.StructField, v string) (string, error)
 )
 
 func (p *Picker) set(obj reflect.Value, i int, val string) error {
+	return p.setTo(p.field(obj, i), obj.Elem().Field(i), val)
+}
+
+// setTo sets v, described by field, to the converted val. Empty
+// values are ignored, except that pointers are allocated.
+func (p *Picker) setTo(field reflect.StructField, v reflect.Value,
+	val string,
+) error {
+	if p.isPointer(field.Type) {
+		return p.setPointer(field, v, val)
+	}
 	if val == "" {
 		return nil
 	}
-	field := obj.Elem().Type().Field(i)
+	val, err := p.convert(field, val)
+	if err != nil {
+		return err
+	}
+	fn, err := p.fieldSetter(field)
+	if err != nil {
+		return err
+	}
+	return fn(v, val)
+}
+
+// convert applies all converters on val in order.
+func (p *Picker) convert(field reflect.StructField, val string) (
+	string, error,
+) {
+	for _, fn := range p.converters {
+		v, err := fn(field, val)
+		if err != nil {
+			return "", err
+		}
+		val = v
+	}
+	return val, nil
+}
+
+// fieldSetter returns the set func for the given field.
+func (p *Picker) fieldSetter(field reflect.StructField) (setfn, error) {
+	if name, found := field.Tag.Lookup("enumOf"); found {
+		return p.enumSetter(name)
+	}
 
-	// find by type here
-	fn, found := p.setters[field.Type.String()]
-	if found {
-		return fn(obj.Elem().Field(i), val)
+	if fn, found := p.typeSetter(field.Type); found {
+		return fn, nil
 	}
 
 	kind := field.Type.Kind()
-	fn, found = p.kindSetters[kind]
-	if !found {
-		return fmt.Errorf("set %v: unsupported", kind)
+	if fn, found := p.kindSetter(kind); found {
+		return fn, nil
 	}
-	return fn(obj.Elem().Field(i), val)
+	return nil, fmt.Errorf("set %v: unsupported", kind)
 }
 
+// typeSetter returns the set func for type t, registered or built
+// in.
+func (p *Picker) typeSetter(t reflect.Type) (setfn, bool) {
+	if fn, found := p.setter(t); found {
+		return fn, true
+	}
+	if fn, found := typeSetters[t]; found {
+		return fn, true
+	}
+	if reflect.PointerTo(t).Implements(setterType) {
+		return setFromString, true
+	}
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return setText, true
+	}
+	return nil, false
+}
+
+// typeSetters are built in set funcs used unless a setter is
+// registered for the type.
+var typeSetters = map[reflect.Type]setfn{
+	timeType:  setTimeField,
+	bytesType: setBytesField,
+}
+
+// setBytesField sets a []byte field to the bytes of v, see field tag
+// encoding for decoding them.
+func setBytesField(field reflect.Value, v string) error {
+	field.SetBytes([]byte(v))
+	return nil
+}
+
+// setText sets field using its encoding.TextUnmarshaler.
+func setText(field reflect.Value, v string) error {
+	u := field.Addr().Interface().(encoding.TextUnmarshaler)
+	return u.UnmarshalText([]byte(v))
+}
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
 func setBoolField(field reflect.Value, val string) error {
 	value, err := strconv.ParseBool(val)
 	if err != nil {
@@ -233,6 +855,17 @@ func setInt64Field(field reflect.Value, val string) error {
 	return nil
 }
 
+// setUintField sets uint and uintptr fields within the range of
+// their size on the platform.
+func setUintField(field reflect.Value, val string) error {
+	value, err := strconv.ParseUint(val, 10, field.Type().Bits())
+	if err != nil {
+		return err
+	}
+	field.SetUint(value)
+	return nil
+}
+
 func setUint8Field(field reflect.Value, val string) error {
 	value, err := strconv.ParseUint(val, 10, 8)
 	if err != nil {
@@ -323,20 +956,60 @@ type Decoder interface {
 }
 
 type PickError struct {
-	// package.type.field
+	// package.type.field, or type name for body errors
 	Dest string
 
-	// (path|query|header|form)[NAME] or body, e.g. header[correlationId]
+	// (path|query|header|cookie|form|request|part)[NAME], body or
+	// validate, e.g. header[correlationId]
 	Source string
 
+	// SourceKind and SourceName are the parts of Source, e.g. header
+	// and correlationId. SourceName is empty for body and validate.
+	SourceKind string
+	SourceName string
+
 	// parsing or set error
 	Cause error
+
+	// Message replaces the generated error message if set, see
+	// field tag errmsg and Picker.SetTranslator
+	Message string
+
+	// Code of the cause and its parameters for localized messages,
+	// e.g. minimum with the limit, see ErrorCode
+	Code   string
+	Params []any
+}
+
+// NewPickError returns a *PickError with the parts of source split
+// into SourceKind and SourceName and Code and Params set from cause,
+// e.g. NewPickError("ID", "path[id]", ErrRequired).
+func NewPickError(dest, source string, cause error) *PickError {
+	kind, name, _ := strings.Cut(strings.TrimSuffix(source, "]"), "[")
+	code, params := ErrorCode(cause)
+	return &PickError{
+		Dest:       dest,
+		Source:     source,
+		SourceKind: kind,
+		SourceName: name,
+		Cause:      cause,
+		Code:       code,
+		Params:     params,
+	}
 }
 
 func (e *PickError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
 	var cause string
 	if e.Cause != nil {
 		cause = strings.Replace(e.Cause.Error(), "strconv.", "", 1)
 	}
 	return fmt.Sprintf("pick %s from %s: %s", e.Dest, e.Source, cause)
 }
+
+// Unwrap returns the cause.
+func (e *PickError) Unwrap() error {
+	return e.Cause
+}
@@ -1,10 +1,15 @@
 package xr
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -228,6 +233,24 @@ func TestPick_int64(t *testing.T) {
 	}
 }
 
+func TestPick_uint(t *testing.T) {
+	var x struct {
+		I uint    `header:"number"`
+		P uintptr `header:"number"`
+	}
+	r := httptest.NewRequest("GET", "/", http.NoBody)
+	r.Header.Set("number", "-1")
+	if err := Pick(&x, r); err == nil {
+		t.Error("expect error")
+	}
+	// ok case
+	max := strconv.FormatUint(uint64(^uint(0)), 10)
+	r.Header.Set("number", max)
+	if err := Pick(&x, r); err != nil || x.I != ^uint(0) {
+		t.Error(x.I, err)
+	}
+}
+
 func TestPick_uint8(t *testing.T) {
 	var x struct {
 		I uint8 `header:"number"`
@@ -391,3 +414,200 @@ func catchPanic(t *testing.T) {
 		t.Fatal("expect panic")
 	}
 }
+
+func TestPicker_SetStrictBody(t *testing.T) {
+	p := NewPicker()
+	p.SetStrictBody(true)
+
+	var x struct {
+		Id string `query:"id"`
+	}
+	body := strings.NewReader(`{"id":"B"}`)
+	r := httptest.NewRequest("GET", "/?id=A", body)
+	if err := p.Pick(&x, r); !errors.Is(err, ErrBodyNotAllowed) {
+		t.Error("expect ErrBodyNotAllowed, got", err)
+	}
+
+	// unknown length
+	r = httptest.NewRequest("DELETE", "/?id=A", body)
+	r.ContentLength = -1
+	if err := p.Pick(&x, r); !errors.Is(err, ErrBodyNotAllowed) {
+		t.Error("expect ErrBodyNotAllowed, got", err)
+	}
+
+	// ok case
+	r = httptest.NewRequest("GET", "/?id=A", http.NoBody)
+	if err := p.Pick(&x, r); err != nil {
+		t.Error(err)
+	}
+}
+
+func Test_hasBody_unknownLength(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", strings.NewReader("abc"))
+	r.ContentLength = -1
+	if !hasBody(r) {
+		t.Fatal("expect body")
+	}
+	// body is restored
+	data, _ := io.ReadAll(r.Body)
+	if got := string(data); got != "abc" {
+		t.Errorf("got %q", got)
+	}
+
+	r = httptest.NewRequest("GET", "/", strings.NewReader(""))
+	r.ContentLength = -1
+	if hasBody(r) {
+		t.Error("unexpected body")
+	}
+}
+
+func TestPick_bodyError(t *testing.T) {
+	body := strings.NewReader(`{broken`)
+	r := httptest.NewRequest("POST", "/", body)
+	r.Header.Set("content-type", "application/json")
+
+	var x Car
+	err := Pick(&x, r)
+	var e *PickError
+	if !errors.As(err, &e) {
+		t.Fatalf("expect *PickError, got %T", err)
+	}
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Error("cause lost", err)
+	}
+	exp := "pick Car from body: application/json: " +
+		"invalid character 'b' looking for beginning of object key string" +
+		` at offset 2 near "{broken"`
+	if got := err.Error(); got != exp {
+		t.Errorf("\ngot %s\nexp %s", got, exp)
+	}
+}
+
+func TestPickError_source(t *testing.T) {
+	var x struct {
+		Id int `header:"x-id"`
+	}
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("x-id", "one")
+	var e *PickError
+	if err := Pick(&x, r); !errors.As(err, &e) {
+		t.Fatal(err)
+	}
+	if e.SourceKind != "header" || e.SourceName != "x-id" {
+		t.Errorf("got %q %q", e.SourceKind, e.SourceName)
+	}
+}
+
+func ExamplePick_cookie() {
+	var x struct {
+		Session string `cookie:"session_id"`
+		Theme   string `cookie:"theme"`
+	}
+	r := httptest.NewRequest("GET", "/", http.NoBody)
+	r.AddCookie(&http.Cookie{Name: "session_id", Value: "abc123"})
+	_ = Pick(&x, r)
+	fmt.Printf("%q %q", x.Session, x.Theme)
+	// output:
+	// "abc123" ""
+}
+
+func ExamplePicker_SetAllErrors() {
+	p := NewPicker()
+	p.SetAllErrors(true)
+	var x struct {
+		Page  int    `query:"page" minimum:"1"`
+		Size  int    `query:"size" maximum:"100"`
+		Order string `query:"order" enum:"asc,desc"`
+	}
+	r := httptest.NewRequest("GET", "/?page=0&size=10&order=up", nil)
+	fmt.Println(p.Pick(&x, r))
+	// output:
+	// pick Page from query[page]: minimum 1: got 0
+	// pick Order from query[order]: enum asc,desc: "up" not allowed
+}
+
+func TestPicker_Register_mediaType(t *testing.T) {
+	p := NewPicker()
+	p.Register("application/json", JSONDecoder)
+	var c Car
+	body := strings.NewReader(`{"sold":true}`)
+	r := httptest.NewRequest("POST", "/", body)
+	r.Header.Set("content-type", "Application/JSON; charset=UTF-8")
+	if err := p.Pick(&c, r); err != nil || !c.Sold {
+		t.Error(c, err)
+	}
+}
+
+func TestPicker_Register_exact(t *testing.T) {
+	p := NewPicker()
+	p.Register("application/json", JSONDecoder)
+	p.Register("application/json; v=2", func(io.Reader) Decoder {
+		return noop
+	})
+	var c Car
+	body := strings.NewReader(`{"sold":true}`)
+	r := httptest.NewRequest("POST", "/", body)
+	r.Header.Set("content-type", "application/json; v=2")
+	if err := p.Pick(&c, r); err != nil || c.Sold {
+		t.Error(c, err)
+	}
+}
+
+func TestPicker_SetStrictMediaType(t *testing.T) {
+	p := NewPicker()
+	p.SetStrictMediaType(true)
+	p.Register("application/json", JSONDecoder)
+	var c Car
+	r := httptest.NewRequest("POST", "/", strings.NewReader("sold: true"))
+	r.Header.Set("content-type", "application/yaml")
+	err := p.Pick(&c, r)
+	if !errors.Is(err, ErrUnsupportedMediaType) || StatusOf(err) != 415 {
+		t.Error("expect ErrUnsupportedMediaType, got", err)
+	}
+	r = httptest.NewRequest("POST", "/", http.NoBody)
+	if err := p.Pick(&c, r); err != nil {
+		t.Error("empty body:", err)
+	}
+}
+
+func ExampleStrictJSONDecoder() {
+	p := NewPicker()
+	p.Register("application/json", StrictJSONDecoder)
+	var c Car
+	body := strings.NewReader(`{"sold":true,"sould":false}`)
+	r := httptest.NewRequest("POST", "/", body)
+	r.Header.Set("content-type", "application/json")
+	fmt.Println(p.Pick(&c, r))
+	// output:
+	// pick Car from body: application/json: json: unknown field "sould"
+}
+
+func TestPicker_Register_concurrent(t *testing.T) {
+	p := NewPicker()
+	p.Register("application/json", JSONDecoder)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			body := strings.NewReader(`{"sold":true}`)
+			r := httptest.NewRequest("POST", "/", body)
+			r.Header.Set("content-type", "application/json")
+			var x struct {
+				Car
+				Email string `header:"x-email" format:"email"`
+			}
+			_ = p.Pick(&x, r)
+		}()
+		go func() {
+			defer wg.Done()
+			name := fmt.Sprintf("x%d", i)
+			p.Register("application/"+name, JSONDecoder)
+			p.UseSetter("x.T"+name, setStringField)
+			p.RegisterFormat(name, func(string) error { return nil })
+			p.RegisterContextKey(name, i)
+		}()
+	}
+	wg.Wait()
+}
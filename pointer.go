@@ -0,0 +1,28 @@
+package xr
+
+import "reflect"
+
+// isPointer returns true if t is a pointer type without a setter,
+// i.e. set by allocating the value it points to.
+func (p *Picker) isPointer(t reflect.Type) bool {
+	if t.Kind() != reflect.Pointer {
+		return false
+	}
+	_, found := p.typeSetter(t)
+	return !found
+}
+
+// setPointer sets pointer v, described by field, to a new value set
+// to val.
+func (p *Picker) setPointer(field reflect.StructField, v reflect.Value,
+	val string,
+) error {
+	elem := field
+	elem.Type = field.Type.Elem()
+	ptr := reflect.New(elem.Type)
+	if err := p.setTo(elem, ptr.Elem(), val); err != nil {
+		return err
+	}
+	v.Set(ptr)
+	return nil
+}
@@ -0,0 +1,64 @@
+package xr
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func ExamplePick_pointer() {
+	var x struct {
+		Limit  *int    `query:"limit"`
+		Offset *int    `query:"offset"`
+		Name   *string `query:"name" notBlank:"true"`
+	}
+	r := httptest.NewRequest("GET", "/?limit=0", nil)
+	_ = Pick(&x, r)
+	fmt.Println(*x.Limit, x.Offset, x.Name)
+	// output:
+	// 0 <nil> <nil>
+}
+
+func TestPick_pointerConverted(t *testing.T) {
+	var x struct {
+		Since *time.Time `query:"since" layout:"2006-01-02"`
+		Ids   []*int     `query:"id"`
+	}
+	r := httptest.NewRequest("GET", "/?since=2024-09-01&id=1&id=2", nil)
+	if err := Pick(&x, r); err != nil {
+		t.Fatal(err)
+	}
+	if x.Since.Day() != 1 || *x.Ids[1] != 2 {
+		t.Error(x.Since, x.Ids)
+	}
+}
+
+func TestPick_pointerInvalid(t *testing.T) {
+	var x struct {
+		Name *string `query:"name" maxLength:"2"`
+		Age  *int    `query:"age"`
+	}
+	for _, q := range []string{"name=abc", "age=x"} {
+		r := httptest.NewRequest("GET", "/?"+q, nil)
+		if err := Pick(&x, r); err == nil {
+			t.Error("expect error for", q)
+		}
+	}
+}
+
+func TestPick_pointerEmpty(t *testing.T) {
+	var x struct {
+		Name  *string `query:"name"`
+		Limit *int    `query:"limit"`
+		Skip  *int    `query:"skip"`
+	}
+	r := httptest.NewRequest("GET", "/?name=&limit=", nil)
+	if err := Pick(&x, r); err != nil {
+		t.Fatal(err)
+	}
+	got := fmt.Sprint(*x.Name == "", *x.Limit, x.Skip)
+	if got != "true 0 <nil>" {
+		t.Error(got)
+	}
+}
@@ -0,0 +1,71 @@
+package xr
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// Problem is a RFC 7807 problem detail with the errors of picking.
+type Problem struct {
+	Type   string         `json:"type,omitempty"`
+	Title  string         `json:"title"`
+	Status int            `json:"status"`
+	Detail string         `json:"detail,omitempty"`
+	Errors []ProblemError `json:"errors,omitempty"`
+}
+
+// ProblemError describes one failed field.
+type ProblemError struct {
+	Field  string `json:"field"`
+	Source string `json:"source"`
+	Name   string `json:"name,omitempty"`
+	Code   string `json:"code,omitempty"`
+	Detail string `json:"detail"`
+}
+
+// NewProblem returns the problem of err, with one entry in Errors for
+// each *PickError, also when joined.
+func NewProblem(err error) *Problem {
+	status := StatusOf(err)
+	p := Problem{
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+	for _, e := range pickErrors(err) {
+		p.Errors = append(p.Errors, ProblemError{
+			Field:  e.Dest,
+			Source: e.SourceKind,
+			Name:   e.SourceName,
+			Code:   e.Code,
+			Detail: e.Error(),
+		})
+	}
+	return &p
+}
+
+// WriteProblem writes err as application/problem+json, see
+// NewProblem. Use it with Picker.SetErrorWriter.
+func WriteProblem(w http.ResponseWriter, r *http.Request, err error) {
+	p := NewProblem(err)
+	w.Header().Set("content-type", "application/problem+json")
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+// pickErrors returns all *PickError found in err, or joined errors
+// of err.
+func pickErrors(err error) []*PickError {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var all []*PickError
+		for _, e := range joined.Unwrap() {
+			all = append(all, pickErrors(e)...)
+		}
+		return all
+	}
+	var e *PickError
+	if errors.As(err, &e) {
+		return []*PickError{e}
+	}
+	return nil
+}
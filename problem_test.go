@@ -0,0 +1,57 @@
+package xr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExampleWriteProblem() {
+	p := NewPicker()
+	p.SetAllErrors(true)
+	var x struct {
+		Page int    `query:"page" minimum:"1"`
+		Id   string `path:"id" required:"true"`
+	}
+	r := httptest.NewRequest("GET", "/?page=0", nil)
+	err := p.Pick(&x, r)
+
+	w := httptest.NewRecorder()
+	WriteProblem(w, r, err)
+	fmt.Println(w.Code, w.Header().Get("content-type"))
+	var body bytes.Buffer
+	_ = json.Indent(&body, w.Body.Bytes(), "", "  ")
+	fmt.Print(body.String())
+	// output:
+	// 400 application/problem+json
+	// {
+	//   "title": "Bad Request",
+	//   "status": 400,
+	//   "errors": [
+	//     {
+	//       "field": "Page",
+	//       "source": "query",
+	//       "name": "page",
+	//       "code": "minimum",
+	//       "detail": "pick Page from query[page]: minimum 1: got 0"
+	//     },
+	//     {
+	//       "field": "Id",
+	//       "source": "path",
+	//       "name": "id",
+	//       "code": "required",
+	//       "detail": "pick Id from path[id]: required"
+	//     }
+	//   ]
+	// }
+}
+
+func TestNewProblem_internal(t *testing.T) {
+	p := NewProblem(errors.New("oops"))
+	if p.Status != 500 || len(p.Errors) != 0 {
+		t.Error(p)
+	}
+}
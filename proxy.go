@@ -0,0 +1,89 @@
+package xr
+
+import (
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// SetTrustedProxies sets the networks of proxies allowed to forward
+// client information using headers such as Forwarded,
+// X-Forwarded-Proto, X-Forwarded-For and X-Real-IP. By default no
+// proxies are trusted.
+func (p *Picker) SetTrustedProxies(networks ...netip.Prefix) {
+	p.trustedProxies = networks
+}
+
+// fromTrustedProxy returns true if the remote address of r is within
+// one of the trusted networks.
+func (p *Picker) fromTrustedProxy(r *http.Request) bool {
+	addr, err := remoteAddr(r)
+	return err == nil && p.isTrusted(addr)
+}
+
+// isTrusted returns true if addr is within one of the trusted
+// networks.
+func (p *Picker) isTrusted(addr netip.Addr) bool {
+	for _, network := range p.trustedProxies {
+		if network.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteAddr returns the address of r.RemoteAddr with or without a
+// port.
+func remoteAddr(r *http.Request) (netip.Addr, error) {
+	if ap, err := netip.ParseAddrPort(r.RemoteAddr); err == nil {
+		return ap.Addr().Unmap(), nil
+	}
+	addr, err := netip.ParseAddr(r.RemoteAddr)
+	return addr.Unmap(), err
+}
+
+// remoteIP returns the IP address of the client sending r. Requests
+// from trusted proxies are followed through X-Forwarded-For, from
+// right to left, to the first address not of a trusted proxy. If
+// there is no such header X-Real-IP is used.
+func (p *Picker) remoteIP(r *http.Request) string {
+	addr, err := remoteAddr(r)
+	if err != nil {
+		return ""
+	}
+	if !p.isTrusted(addr) {
+		return addr.String()
+	}
+	if client := p.forwardedFor(r); client.IsValid() {
+		return client.String()
+	}
+	return addr.String()
+}
+
+// forwardedFor returns the last forwarded address not of a trusted
+// proxy, or the first if all are trusted. The address is invalid if
+// any of the inspected ones are.
+func (p *Picker) forwardedFor(r *http.Request) netip.Addr {
+	var client netip.Addr
+	hops := forwardedHops(r)
+	for i := len(hops) - 1; i >= 0; i-- {
+		addr, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			return netip.Addr{}
+		}
+		client = addr.Unmap()
+		if !p.isTrusted(client) {
+			break
+		}
+	}
+	return client
+}
+
+// forwardedHops returns all addresses of X-Forwarded-For headers, or
+// the X-Real-IP header.
+func forwardedHops(r *http.Request) []string {
+	if v := strings.Join(r.Header.Values("X-Forwarded-For"), ","); v != "" {
+		return strings.Split(v, ",")
+	}
+	return nonEmpty(r.Header.Get("X-Real-IP"))
+}
@@ -0,0 +1,64 @@
+package xr
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// SetCaseInsensitiveQuery controls if query parameters match names
+// of query tags regardless of case, e.g. ?ID=5 for `query:"id"`.
+// Parameters with the exact name are preferred.
+func (p *Picker) SetCaseInsensitiveQuery(v bool) {
+	if v {
+		p.readers["query"] = readQueryFold
+		return
+	}
+	p.readers["query"] = valueReaders["query"]
+}
+
+// readQueryFold returns values of the query parameter with the given
+// name, or of all parameters equal to name under case folding if
+// there is no exact match.
+func readQueryFold(r *http.Request, name string) []string {
+	query := queryOf(r)
+	if values, found := query[name]; found {
+		return values
+	}
+	var values []string
+	for k, v := range query {
+		if strings.EqualFold(k, name) {
+			values = append(values, v...)
+		}
+	}
+	return values
+}
+
+// readQuery returns values of the query parameter with the given
+// name.
+func readQuery(r *http.Request, name string) []string {
+	return queryOf(r)[name]
+}
+
+// parseQuery parses the query of r once for the duration of a pick,
+// instead of once per field. The returned func releases it.
+func parseQuery(r *http.Request) func() {
+	_, loaded := parsedQueries.LoadOrStore(r, r.URL.Query())
+	if loaded {
+		// picking r within a pick, e.g. in a Validate method
+		return func() {}
+	}
+	return func() { parsedQueries.Delete(r) }
+}
+
+// queryOf returns the query of r, parsed once while it's picked.
+func queryOf(r *http.Request) url.Values {
+	if query, found := parsedQueries.Load(r); found {
+		return query.(url.Values)
+	}
+	return r.URL.Query()
+}
+
+// parsedQueries of requests being picked
+var parsedQueries sync.Map
@@ -0,0 +1,57 @@
+package xr
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExampleWithCaseInsensitiveQuery() {
+	p := NewPicker(WithCaseInsensitiveQuery())
+	var x struct {
+		ID int `query:"id"`
+	}
+	r := httptest.NewRequest("GET", "/?ID=5", http.NoBody)
+	_ = p.Pick(&x, r)
+	fmt.Println(x.ID)
+	// output:
+	// 5
+}
+
+func TestPicker_SetCaseInsensitiveQuery(t *testing.T) {
+	p := NewPicker()
+	var x struct {
+		ID string `query:"id"`
+	}
+	r := httptest.NewRequest("GET", "/?Id=1&id=2", http.NoBody)
+	p.SetCaseInsensitiveQuery(true)
+	_ = p.Pick(&x, r)
+	if x.ID != "2" {
+		t.Error("exact name not preferred:", x.ID)
+	}
+
+	x.ID = ""
+	r = httptest.NewRequest("GET", "/?ID=1", http.NoBody)
+	p.SetCaseInsensitiveQuery(false)
+	_ = p.Pick(&x, r)
+	if x.ID != "" {
+		t.Error("case insensitive when disabled:", x.ID)
+	}
+}
+
+func Test_parseQuery(t *testing.T) {
+	var x struct {
+		ID int `query:"id"`
+	}
+	r := httptest.NewRequest("GET", "/?id=1", http.NoBody)
+	_ = Pick(&x, r)
+	if _, found := parsedQueries.Load(r); found {
+		t.Error("parsed query kept after Pick")
+	}
+	r.URL.RawQuery = "id=2"
+	_ = Pick(&x, r)
+	if x.ID != 2 {
+		t.Error("got", x.ID)
+	}
+}
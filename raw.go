@@ -0,0 +1,86 @@
+package xr
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+)
+
+// readRawBody sets the field of dst tagged `body:""`, nested ones
+// included, to the raw body of r. Fields of type []byte,
+// json.RawMessage or string get the whole body, which is kept in
+// memory and replaced so it can still be decoded. An io.Reader field
+// gets the body for streaming, limited by SetMaxBodyBytes, in which
+// case true is returned as the body must not be decoded.
+func (p *Picker) readRawBody(dst any, r *http.Request) (bool, error) {
+	field := p.rawBodyField(reflect.ValueOf(dst))
+	switch {
+	case !field.IsValid(), r.Body == nil:
+		return false, nil
+
+	case field.Type() == readerType:
+		field.Set(reflect.ValueOf(r.Body))
+		return true, nil
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return false, err
+	}
+	r.Body = readCloser{Reader: bytes.NewReader(data), Closer: r.Body}
+	return false, setRawBody(field, data)
+}
+
+// rawBodyField returns the field of the struct obj points to, or of
+// its nested structs, tagged with an empty body tag. The returned
+// value is invalid if there is no such field.
+func (p *Picker) rawBodyField(obj reflect.Value) reflect.Value {
+	for i := 0; i < obj.Elem().NumField(); i++ {
+		field := p.field(obj, i)
+		if isRawBody(field) {
+			return obj.Elem().Field(i)
+		}
+		if v := p.nestedRawBodyField(obj, i); v.IsValid() {
+			return v
+		}
+	}
+	return reflect.Value{}
+}
+
+// nestedRawBodyField returns the raw body field of nested struct
+// field i of obj, if any.
+func (p *Picker) nestedRawBodyField(obj reflect.Value, i int) reflect.Value {
+	if !p.isNested(p.field(obj, i)) {
+		return reflect.Value{}
+	}
+	v := obj.Elem().Field(i)
+	allocNested(v)
+	return p.rawBodyField(nestedPtr(v))
+}
+
+// isRawBody returns true if field is tagged `body:""`.
+func isRawBody(field reflect.StructField) bool {
+	name, found := field.Tag.Lookup("body")
+	return found && name == ""
+}
+
+func setRawBody(field reflect.Value, data []byte) error {
+	switch {
+	case isBytes(field.Type()):
+		field.SetBytes(data)
+
+	case field.Kind() == reflect.String:
+		field.SetString(string(data))
+
+	default:
+		return fmt.Errorf("body %v: unsupported", field.Type())
+	}
+	return nil
+}
+
+// isBytes returns true if t is a slice of bytes, e.g. []byte or
+// json.RawMessage.
+func isBytes(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
@@ -0,0 +1,105 @@
+package xr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func ExamplePick_rawBody() {
+	var x struct {
+		Name string `json:"name"`
+		Raw  []byte `body:"" json:"-"`
+	}
+	body := strings.NewReader(`{"name": "john"}`)
+	r := httptest.NewRequest("POST", "/", body)
+	r.Header.Set("content-type", "application/json")
+	_ = Pick(&x, r)
+	fmt.Println(x.Name, string(x.Raw))
+	// output:
+	// john {"name": "john"}
+}
+
+func TestPick_rawBody(t *testing.T) {
+	var x struct {
+		Text string `body:""`
+	}
+	r := httptest.NewRequest("POST", "/", strings.NewReader("hello"))
+	if err := Pick(&x, r); err != nil || x.Text != "hello" {
+		t.Error(x.Text, err)
+	}
+
+	var y struct {
+		Raw int `body:""`
+	}
+	r = httptest.NewRequest("POST", "/", strings.NewReader("1"))
+	if err := Pick(&y, r); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestPick_rawBodyReader(t *testing.T) {
+	var x struct {
+		Reader io.Reader `body:""`
+	}
+	r := httptest.NewRequest("POST", "/", strings.NewReader("hello"))
+	_ = Pick(&x, r)
+	data, _ := io.ReadAll(x.Reader)
+	if string(data) != "hello" {
+		t.Error(string(data))
+	}
+}
+
+func TestPick_rawBodyReaderStreamed(t *testing.T) {
+	var x struct {
+		Name   string    `json:"name"`
+		Reader io.Reader `body:""`
+	}
+	p := NewPicker()
+	p.SetMaxBodyBytes(8)
+	body := strings.NewReader(`{"name": "john"}`)
+	r := httptest.NewRequest("POST", "/", body)
+	r.Header.Set("content-type", "application/json")
+	r.ContentLength = -1 // only known when read
+	if err := p.Pick(&x, r); err != nil || x.Name != "" {
+		t.Fatal(x.Name, err)
+	}
+	data, err := io.ReadAll(x.Reader)
+	if string(data) != `{"name":` || !errors.Is(err, ErrTooLarge) {
+		t.Error(string(data), err)
+	}
+}
+
+func TestPick_rawBodyNested(t *testing.T) {
+	type Raw struct {
+		Text string `body:""`
+	}
+	var x struct {
+		Raw
+	}
+	r := httptest.NewRequest("POST", "/", strings.NewReader("hello"))
+	if err := Pick(&x, r); err != nil || x.Text != "hello" {
+		t.Error(x.Text, err)
+	}
+}
+
+func ExamplePick_rawMessage() {
+	var x struct {
+		Kind string          `json:"kind"`
+		Data json.RawMessage `json:"data"`
+		Body json.RawMessage `body:"" json:"-"`
+	}
+	body := strings.NewReader(`{"kind":"order","data":{"id":1}}`)
+	r := httptest.NewRequest("POST", "/", body)
+	r.Header.Set("content-type", "application/json")
+	_ = Pick(&x, r)
+	fmt.Println(x.Kind, string(x.Data))
+	fmt.Println(string(x.Body))
+	// output:
+	// order {"id":1}
+	// {"kind":"order","data":{"id":1}}
+}
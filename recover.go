@@ -0,0 +1,57 @@
+package xr
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+)
+
+// PickPanic is the panic value of Pick when misused, e.g. with a
+// non-pointer destination or a private field tagged for picking.
+type PickPanic struct {
+	// Value describes the misuse
+	Value any
+
+	// Stack of the panicking goroutine
+	Stack []byte
+}
+
+func (e *PickPanic) Error() string {
+	return fmt.Sprintf("pick panic: %v", e.Value)
+}
+
+// misuse returns the *PickPanic to panic with when Pick is misused,
+// capturing the stack of the caller. Panics of setters, validators
+// and decoders are left as is.
+func misuse(format string, args ...any) *PickPanic {
+	return &PickPanic{
+		Value: fmt.Sprintf(format, args...),
+		Stack: debug.Stack(),
+	}
+}
+
+// Recover returns a handler recovering *PickPanic from next. The
+// panic is logged, if configured with SetErrorLog, and written as a
+// 500 using the error writer, see SetErrorWriter. Other panics are
+// left as is.
+func (p *Picker) Recover(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer p.recoverPick(w, r)
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (p *Picker) recoverPick(w http.ResponseWriter, r *http.Request) {
+	v := recover()
+	e, ok := v.(*PickPanic)
+	if v != nil && !ok {
+		panic(v) // runtime trace keeps the original frames
+	}
+	if !ok {
+		return
+	}
+	if p.errorLog != nil {
+		p.errorLog.Printf("%s %s: %v\n%s", r.Method, r.URL.Path, e, e.Stack)
+	}
+	p.errorWriter(w, r, e)
+}
@@ -0,0 +1,78 @@
+package xr
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func ExampleRecover() {
+	h := Recover(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			var x struct {
+				id string `query:"id"` // private
+			}
+			_ = Pick(&x, r)
+		},
+	))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/?id=1", nil))
+	fmt.Print(w.Code, " ", w.Body.String())
+	// output:
+	// 500 {"error":"Internal Server Error"}
+}
+
+func TestPicker_Recover_log(t *testing.T) {
+	p := NewPicker()
+	var buf bytes.Buffer
+	p.SetErrorLog(log.New(&buf, "", 0))
+	h := p.Recover(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			var x struct{}
+			_ = p.Pick(x, r)
+		},
+	))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	if !strings.Contains(buf.String(), "dst must be a pointer") {
+		t.Error(buf.String())
+	}
+}
+
+func TestPicker_Recover_other(t *testing.T) {
+	defer catchPanic(t)
+	h := Recover(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			panic("handler")
+		},
+	))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+}
+
+func TestPick_misuseStack(t *testing.T) {
+	defer func() {
+		e, ok := recover().(*PickPanic)
+		if !ok || !bytes.Contains(e.Stack, []byte("PickInto")) {
+			t.Error(e)
+		}
+	}()
+	var x struct{}
+	_ = Pick(x, httptest.NewRequest("GET", "/", nil))
+}
+
+func TestPick_setterPanic(t *testing.T) {
+	p := NewPicker()
+	UseSetterFor(p, func(string) (Color, error) { panic("setter") })
+	defer func() {
+		if v := recover(); v != "setter" {
+			t.Errorf("%#v", v)
+		}
+	}()
+	var x struct {
+		C Color `query:"c"`
+	}
+	_ = p.Pick(&x, httptest.NewRequest("GET", "/?c=red", nil))
+}
@@ -0,0 +1,23 @@
+package xr
+
+import (
+	"errors"
+	"reflect"
+)
+
+// ErrRequired is the cause when a field tagged `required:"true"` has
+// no source value.
+var ErrRequired = errors.New("required")
+
+// pickMissing handles field i of obj without a source value. It's
+// generated if tagged generate or an error if required.
+func (p *Picker) pickMissing(obj reflect.Value, i int, src source) error {
+	field := p.field(obj, i)
+	if _, found := field.Tag.Lookup("generate"); found {
+		return p.pickGenerated(obj, i, src)
+	}
+	if isTagged(field.Tag, "required") {
+		return NewPickError(field.Name, src.String(), ErrRequired)
+	}
+	return nil
+}
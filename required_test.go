@@ -0,0 +1,34 @@
+package xr
+
+import (
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExamplePick_required() {
+	var x struct {
+		Token string `header:"authorization" required:"true"`
+	}
+	r := httptest.NewRequest("GET", "/", nil)
+	fmt.Println(Pick(&x, r))
+	// output:
+	// pick Token from header[authorization]: required
+}
+
+func TestPick_required(t *testing.T) {
+	var x struct {
+		Id    string `query:"id" required:"true"`
+		Trace string `header:"x-trace" required:"true" generate:"uuid"`
+		Page  int    `query:"page" required:"false"`
+	}
+	r := httptest.NewRequest("GET", "/", nil)
+	if err := Pick(&x, r); !errors.Is(err, ErrRequired) {
+		t.Error("expect ErrRequired, got", err)
+	}
+	r = httptest.NewRequest("GET", "/?id=1", nil)
+	if err := Pick(&x, r); err != nil || x.Trace == "" {
+		t.Error(x, err)
+	}
+}
@@ -0,0 +1,153 @@
+package xr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Encoder writes values to a response body.
+type Encoder interface {
+	Encode(v any) error
+}
+
+// RegisterEncoder registers the response body encoder for a
+// content-type, see Respond. The first registered encoder is used
+// when a request accepts any media type. Safe to call while
+// responding.
+func (p *Picker) RegisterEncoder(contentType string,
+	fn func(io.Writer) Encoder,
+) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, found := p.encoders[contentType]; !found {
+		p.encoderOrder = append(p.encoderOrder, contentType)
+	}
+	p.encoders[contentType] = fn
+}
+
+// Respond writes v with the given status, encoded by the registered
+// encoder most preferred by the accept header of r, and sets the
+// content-type header. Preference follows quality values and the
+// most specific matching media range, e.g. "text/*;q=0.5" or "*/*".
+// Equally preferred encoders are chosen in registration order and
+// requests without an accept header get the first registered
+// encoder. Respond returns ErrNotAcceptable, before writing
+// anything, if no encoder is accepted.
+func (p *Picker) Respond(w http.ResponseWriter, r *http.Request,
+	status int, v any,
+) error {
+	ct, fn, err := p.encoderFor(r.Header.Values("accept"))
+	if err != nil {
+		return err
+	}
+	w.Header().Set("content-type", ct)
+	w.WriteHeader(status)
+	return fn(w).Encode(v)
+}
+
+// ErrNotAcceptable is returned by Respond if there is no encoder
+// for the media types accepted by a request.
+var ErrNotAcceptable = errors.New("not acceptable")
+
+// encoderFor returns the content-type and encoder most preferred by
+// the accept header values.
+func (p *Picker) encoderFor(accept []string) (
+	string, func(io.Writer) Encoder, error,
+) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	ranges := parseAccept(accept)
+	var best string
+	var bestQ float64
+	for _, ct := range p.encoderOrder {
+		if q := quality(ranges, ct); q > bestQ {
+			best, bestQ = ct, q
+		}
+	}
+	if best == "" {
+		v := strings.Join(accept, ",")
+		return "", nil, fmt.Errorf("%w: %q", ErrNotAcceptable, v)
+	}
+	return best, p.encoders[best], nil
+}
+
+// encoderOf returns the encoder registered for content-type ct.
+func (p *Picker) encoderOf(ct string) (func(io.Writer) Encoder, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	fn, found := p.encoders[ct]
+	if !found {
+		return nil, fmt.Errorf("%w: no %s encoder", ErrNotAcceptable, ct)
+	}
+	return fn, nil
+}
+
+// mediaRange of an accept header, e.g. text/* with quality 0.5.
+type mediaRange struct {
+	mediaType string
+	q         float64
+}
+
+// parseAccept returns the media ranges of accept header values, */*
+// if there are none. Quality values default to 1.
+func parseAccept(accept []string) []mediaRange {
+	var ranges []mediaRange
+	for _, v := range accept {
+		for _, part := range strings.Split(v, ",") {
+			mediaType, params, err := mime.ParseMediaType(part)
+			if err == nil {
+				q := parseQuality(params["q"])
+				ranges = append(ranges, mediaRange{mediaType, q})
+			}
+		}
+	}
+	if len(ranges) == 0 {
+		return []mediaRange{{"*/*", 1}}
+	}
+	return ranges
+}
+
+// parseQuality returns the quality value v, 1 if empty or invalid.
+func parseQuality(v string) float64 {
+	q, err := strconv.ParseFloat(v, 64)
+	if err != nil || q < 0 || q > 1 {
+		return 1
+	}
+	return q
+}
+
+// quality returns the quality of the most specific range matching
+// media type ct, 0 if none.
+func quality(ranges []mediaRange, ct string) float64 {
+	var q float64
+	specificity := -1
+	for _, r := range ranges {
+		s := matches(r.mediaType, ct)
+		if s > specificity {
+			q, specificity = r.q, s
+		}
+	}
+	return q
+}
+
+// matches returns the specificity of media range pattern matching
+// ct; 2 for exact matches, 1 for type/*, 0 for */* and -1 if not
+// matching.
+func matches(pattern, ct string) int {
+	typ, _, _ := strings.Cut(ct, "/")
+	switch pattern {
+	case ct:
+		return 2
+	case typ + "/*":
+		return 1
+	case "*/*":
+		return 0
+	default:
+		return -1
+	}
+}
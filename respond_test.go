@@ -0,0 +1,71 @@
+package xr
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExampleRespond() {
+	h := Handler(func(w http.ResponseWriter, r *http.Request) error {
+		var x struct {
+			Name string `query:"name" json:"name"`
+		}
+		if err := Pick(&x, r); err != nil {
+			return err
+		}
+		return Respond(w, r, http.StatusOK, x)
+	})
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/?name=John", nil)
+	r.Header.Set("accept", "text/html, application/json")
+	h.ServeHTTP(w, r)
+	fmt.Print(w.Header().Get("content-type"), " ", w.Body.String())
+	// output:
+	// application/json {"name":"John"}
+}
+
+func TestPicker_Respond(t *testing.T) {
+	p := NewPicker()
+	p.RegisterEncoder("application/xml", func(w io.Writer) Encoder {
+		return xml.NewEncoder(w)
+	})
+	p.RegisterEncoder("application/json", JSONEncoder)
+	cases := map[string]string{
+		"":                        "application/xml",
+		"*/*":                     "application/xml",
+		"application/json; q=0.5": "application/json",
+		"text/html, */*":          "application/xml",
+
+		"application/xml;q=0.9, application/json": "application/json",
+		"application/*;q=0.2, */*;q=0.1":          "application/xml",
+		"*/*, application/xml;q=0":                "application/json",
+		"application/*, application/xml;q=bad":    "application/xml",
+	}
+	for accept, exp := range cases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("accept", accept)
+		_ = p.Respond(w, r, http.StatusCreated, 1)
+		if got := w.Header().Get("content-type"); got != exp {
+			t.Errorf("%q: got %q, expected %q", accept, got, exp)
+		}
+	}
+}
+
+func TestPicker_Respond_notAcceptable(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("accept", "text/*, application/json;q=0")
+	err := Respond(w, r, http.StatusOK, 1)
+	if !errors.Is(err, ErrNotAcceptable) {
+		t.Error("expect ErrNotAcceptable, got", err)
+	}
+	if StatusOf(err) != http.StatusNotAcceptable {
+		t.Error(StatusOf(err))
+	}
+}
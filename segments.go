@@ -0,0 +1,52 @@
+package xr
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// segments returns v with the path segments of its value, if field
+// is a slice tagged path with separator /, e.g. `path:"rest" sep:"/"`
+// of pattern /files/{rest...}. Escaped slashes, %2F, are kept within
+// segments.
+func (p *Picker) segments(r *http.Request, field reflect.StructField,
+	v value,
+) value {
+	if !isSegmented(field, v.kind) || !p.isSlice(field) {
+		return v
+	}
+	v.all = pathSegments(r.URL.EscapedPath(), v.all[0])
+	return v
+}
+
+// isSegmented returns true if field of the source kind is split into
+// path segments, i.e. tagged path and `sep:"/"` without a style.
+func isSegmented(field reflect.StructField, kind string) bool {
+	_, style := field.Tag.Lookup("style")
+	return kind == "path" && field.Tag.Get("sep") == "/" && !style
+}
+
+// pathSegments returns the unescaped segments of the end of escaped
+// path matching the wildcard value.
+func pathSegments(escaped, value string) []string {
+	rest := escaped
+	for {
+		if u, err := url.PathUnescape(rest); err == nil && u == value {
+			return unescapeAll(strings.Split(rest, "/"))
+		}
+		_, after, found := strings.Cut(rest, "/")
+		if !found {
+			return strings.Split(value, "/")
+		}
+		rest = after
+	}
+}
+
+func unescapeAll(segments []string) []string {
+	for i, s := range segments {
+		segments[i], _ = url.PathUnescape(s)
+	}
+	return segments
+}
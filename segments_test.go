@@ -0,0 +1,59 @@
+package xr
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExamplePick_pathWildcard() {
+	var x struct {
+		Path     string   `path:"rest"`
+		Segments []string `path:"rest" sep:"/"`
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/files/{rest...}",
+		func(w http.ResponseWriter, r *http.Request) {
+			_ = Pick(&x, r)
+		},
+	)
+	r := httptest.NewRequest("GET", "/files/docs/a%2Fb/c.txt", nil)
+	mux.ServeHTTP(httptest.NewRecorder(), r)
+	fmt.Printf("%s %q\n", x.Path, x.Segments)
+	// output:
+	// docs/a/b/c.txt ["docs" "a/b" "c.txt"]
+}
+
+func TestPick_pathSegmentsExplicit(t *testing.T) {
+	var x struct {
+		All   []string `path:"rest"`
+		Pipes []string `path:"rest" sep:"/" style:"pipeDelimited"`
+	}
+	r := httptest.NewRequest("GET", "/files/a/b|c", nil)
+	r.SetPathValue("rest", "a/b|c")
+	if err := Pick(&x, r); err != nil {
+		t.Fatal(err)
+	}
+	got := fmt.Sprintf("%q %q", x.All, x.Pipes)
+	if exp := `["a/b|c"] ["a/b" "c"]`; got != exp {
+		t.Errorf("got %s, exp %s", got, exp)
+	}
+}
+
+func Test_pathSegments(t *testing.T) {
+	cases := []struct {
+		escaped, value, exp string
+	}{
+		{"/files/a/b", "a/b", "[a b]"},
+		{"/files/a%2Fb", "a/b", "[a/b]"},
+		{"/a/b", "b", "[b]"},
+		{"/x", "a/b", "[a b]"},
+	}
+	for _, c := range cases {
+		got := fmt.Sprint(pathSegments(c.escaped, c.value))
+		if got != c.exp {
+			t.Errorf("%+v: got %s", c, got)
+		}
+	}
+}
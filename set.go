@@ -0,0 +1,24 @@
+package xr
+
+import "reflect"
+
+// UseSetterFor registers parse func for fields of type T, e.g.
+//
+//	UseSetterFor(p, netip.ParseAddr)
+//
+// Panics if a setter for T already exists.
+func UseSetterFor[T any](p *Picker, parse func(string) (T, error)) {
+	p.UseTypeSetter(reflect.TypeFor[T](), setterOf(parse))
+}
+
+// setterOf returns a set func using the given parse func.
+func setterOf[T any](parse func(string) (T, error)) setfn {
+	return func(field reflect.Value, v string) error {
+		value, err := parse(v)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(&value).Elem())
+		return nil
+	}
+}
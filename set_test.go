@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/netip"
 	"reflect"
 	"testing"
 )
@@ -15,6 +16,25 @@ func TestPicker_UseSetter_duplicate(t *testing.T) {
 	p.UseSetter("xr.Color", SetColorField)
 }
 
+func TestPicker_UseTypeSetter(t *testing.T) {
+	p := NewPicker()
+	p.UseTypeSetter(reflect.TypeFor[Color](), SetColorField)
+	var x struct {
+		C Color `query:"c"`
+	}
+	r := httptest.NewRequest("GET", "/?c=red", http.NoBody)
+	if err := p.Pick(&x, r); err != nil || x.C != Red {
+		t.Error(x.C, err)
+	}
+}
+
+func TestPicker_UseTypeSetter_duplicate(t *testing.T) {
+	defer catchPanic(t)
+	p := NewPicker()
+	UseSetterFor(p, ParseColor)
+	p.UseTypeSetter(reflect.TypeFor[Color](), SetColorField)
+}
+
 func TestPicker_typeX(t *testing.T) {
 	// Configure picker to use our set func for the specific type.
 	// Using global UseSetter in this test for coverage.
@@ -54,11 +74,24 @@ func TestPicker_typeX_fail(t *testing.T) {
 type Color int
 
 const (
-	Black = iota
+	Black Color = iota
 	Red
 	Yellow
 )
 
+func (c Color) String() string {
+	switch c {
+	case Black:
+		return "black"
+	case Red:
+		return "red"
+	case Yellow:
+		return "yellow"
+	default:
+		return fmt.Sprintf("Color(%d)", int(c))
+	}
+}
+
 func SetColorField(field reflect.Value, v string) error {
 	color, err := ParseColor(v)
 	if err != nil {
@@ -80,3 +113,40 @@ func ParseColor(v string) (Color, error) {
 		return Black, fmt.Errorf("unknown color: %v", v)
 	}
 }
+
+func ExampleUseSetterFor() {
+	p := NewPicker()
+	UseSetterFor(p, ParseColor)
+	UseSetterFor(p, netip.ParseAddr)
+
+	var x struct {
+		Color Color      `query:"color"`
+		Addr  netip.Addr `header:"x-real-ip"`
+	}
+	r := httptest.NewRequest("GET", "/?color=red", http.NoBody)
+	r.Header.Set("x-real-ip", "192.0.2.1")
+	_ = p.Pick(&x, r)
+	fmt.Println(x.Color == Red, x.Addr)
+
+	r.Header.Set("x-real-ip", "x")
+	fmt.Println(p.Pick(&x, r))
+	// output:
+	// true 192.0.2.1
+	// pick Addr from header[x-real-ip]: ParseAddr("x"): unable to parse IP
+}
+
+func ExamplePick_textUnmarshaler() {
+	var x struct {
+		Addr   netip.Addr       `header:"x-real-ip"`
+		Prefix netip.Prefix     `query:"net"`
+		Ports  []netip.AddrPort `query:"peer"`
+	}
+	r := httptest.NewRequest(
+		"GET", "/?net=192.0.2.0/24&peer=192.0.2.7:80", http.NoBody,
+	)
+	r.Header.Set("x-real-ip", "192.0.2.1")
+	_ = Pick(&x, r)
+	fmt.Println(x.Addr, x.Prefix, x.Ports)
+	// output:
+	// 192.0.2.1 192.0.2.0/24 [192.0.2.7:80]
+}
@@ -0,0 +1,24 @@
+package xr
+
+import "reflect"
+
+// Setter is implemented by field types setting themselves from
+// source values. It's used without registration and before
+// encoding.TextUnmarshaler, e.g.
+//
+//	type Sort struct{ Field string; Desc bool }
+//
+//	func (s *Sort) SetFromString(v string) error {
+//		s.Field, s.Desc = strings.CutPrefix(v, "-")
+//		return nil
+//	}
+type Setter interface {
+	SetFromString(v string) error
+}
+
+// setFromString sets field using its Setter.
+func setFromString(field reflect.Value, v string) error {
+	return field.Addr().Interface().(Setter).SetFromString(v)
+}
+
+var setterType = reflect.TypeFor[Setter]()
@@ -0,0 +1,46 @@
+package xr
+
+import (
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+func (s *Sort) SetFromString(v string) error {
+	if v == "" || v == "-" {
+		return errors.New("empty")
+	}
+	field, desc := strings.CutPrefix(v, "-")
+	s.Field, s.Desc = field, desc
+	return nil
+}
+
+func ExampleSetter() {
+	var x struct {
+		Sort  Sort   `query:"sort"`
+		Then  *Sort  `query:"then"`
+		Other []Sort `query:"o" sep:","`
+	}
+	r := httptest.NewRequest("GET", "/?sort=-age&then=name&o=a,-b", nil)
+	_ = Pick(&x, r)
+	fmt.Println(x.Sort, *x.Then, x.Other)
+	// output:
+	// {age true} {name false} [{a false} {b true}]
+}
+
+func TestSetter_error(t *testing.T) {
+	var x struct {
+		Sort Sort `query:"sort"`
+	}
+	r := httptest.NewRequest("GET", "/?sort=-", nil)
+	if err := Pick(&x, r); err == nil {
+		t.Error("expect error")
+	}
+}
@@ -0,0 +1,82 @@
+package xr
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// isSlice returns true if field is a slice set element wise, i.e.
+// not []byte or a slice type with a registered setter.
+func (p *Picker) isSlice(field reflect.StructField) bool {
+	_, found := p.setter(field.Type)
+	return field.Type.Kind() == reflect.Slice &&
+		field.Type != bytesType && !found
+}
+
+// pickSlice sets slice field i of obj to all values, each split by
+// the separator of field tag sep if given, e.g. `sep:","`, or style.
+func (p *Picker) pickSlice(obj reflect.Value, i int, v value) error {
+	field := p.field(obj, i)
+	elem := field
+	elem.Type = field.Type.Elem()
+	values, err := sliceValues(field, v)
+	if err != nil {
+		return NewPickError(field.Name, v.source().String(), err)
+	}
+	slice := reflect.MakeSlice(field.Type, len(values), len(values))
+	for j, val := range values {
+		if err := p.setTo(elem, slice.Index(j), val); err != nil {
+			return NewPickError(field.Name, v.source().String(), err)
+		}
+	}
+	obj.Elem().Field(i).Set(slice)
+	return p.validate(obj, i, v.source())
+}
+
+// sliceValues returns all values of v split by the separator of
+// field, except path segments which are already split, see segments.
+func sliceValues(field reflect.StructField, v value) ([]string, error) {
+	if isSegmented(field, v.kind) {
+		return v.all, nil
+	}
+	sep, err := separator(field)
+	return split(v.all, sep), err
+}
+
+// split returns all values split by sep with surrounding space
+// removed. Values are returned as is if sep is empty.
+func split(values []string, sep string) []string {
+	if sep == "" {
+		return values
+	}
+	var all []string
+	for _, v := range values {
+		for _, part := range strings.Split(v, sep) {
+			all = append(all, strings.TrimSpace(part))
+		}
+	}
+	return all
+}
+
+// separator returns the separator of values of field given by field
+// tag sep or style, e.g. `style:"pipeDelimited"`.
+func separator(field reflect.StructField) (string, error) {
+	style, found := field.Tag.Lookup("style")
+	if !found {
+		return field.Tag.Get("sep"), nil
+	}
+	sep, found := styleSeparators[style]
+	if !found {
+		return "", fmt.Errorf("style %s: unknown", style)
+	}
+	return sep, nil
+}
+
+// styleSeparators of OpenAPI serialization styles of arrays. Values
+// of style form are repeated, e.g. ?id=1&id=2.
+var styleSeparators = map[string]string{
+	"form":           "",
+	"spaceDelimited": " ",
+	"pipeDelimited":  "|",
+}
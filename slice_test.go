@@ -0,0 +1,65 @@
+package xr
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExamplePick_sep() {
+	var x struct {
+		Tags []string `query:"tags" sep:","`
+		Ids  []int    `header:"x-ids" sep:" "`
+		Page []int    `query:"page"`
+	}
+	r := httptest.NewRequest("GET", "/?tags=a,b,c&page=1&page=2", nil)
+	r.Header.Set("x-ids", "10 20")
+	_ = Pick(&x, r)
+	fmt.Println(x.Tags, x.Ids, x.Page)
+	// output:
+	// [a b c] [10 20] [1 2]
+}
+
+func ExamplePick_style() {
+	var x struct {
+		Tags []string `query:"tags" style:"pipeDelimited"`
+		Ids  []int    `query:"ids" style:"spaceDelimited"`
+	}
+	r := httptest.NewRequest("GET", "/?tags=a|b&ids=1%202%203", nil)
+	_ = Pick(&x, r)
+	fmt.Println(x.Tags, x.Ids)
+	// output:
+	// [a b] [1 2 3]
+}
+
+func TestPick_styleUnknown(t *testing.T) {
+	var x struct {
+		Ids []int `query:"ids" style:"deepObject"`
+	}
+	r := httptest.NewRequest("GET", "/?ids=1", nil)
+	if err := Pick(&x, r); err == nil {
+		t.Error("expect error", x.Ids)
+	}
+}
+
+func TestPick_sliceError(t *testing.T) {
+	var x struct {
+		Ids []int `query:"ids" sep:","`
+	}
+	r := httptest.NewRequest("GET", "/?ids=1,two", nil)
+	if err := Pick(&x, r); err == nil {
+		t.Error("expect error", x.Ids)
+	}
+}
+
+func TestPick_sliceEnum(t *testing.T) {
+	p := NewPicker()
+	p.RegisterEnum("Color", EnumOf(Black, Red, Yellow))
+	var x struct {
+		Colors []Color `query:"c" sep:"|" enumOf:"Color"`
+	}
+	r := httptest.NewRequest("GET", "/?c=red|yellow", nil)
+	if err := p.Pick(&x, r); err != nil || len(x.Colors) != 2 {
+		t.Error(x.Colors, err)
+	}
+}
@@ -0,0 +1,86 @@
+package xr
+
+import (
+	"net/http"
+	"reflect"
+)
+
+// SourceField is a struct field picked from a named source.
+type SourceField struct {
+	// Kind of source, e.g. query, and the name within it including
+	// prefixes of nested structs, e.g. address.street
+	Kind string
+	Name string
+
+	// Field with the tags of a mapping, see Picker.UseMapping
+	Field reflect.StructField
+}
+
+// SourceFields returns the fields of struct, or pointer to struct, v
+// tagged with one of the given source kinds, e.g. query, including
+// those of nested structs. Names are resolved as when picking, i.e.
+// with prefixes, naming and mappings applied.
+func (p *Picker) SourceFields(v any, kinds ...string) []SourceField {
+	t := reflect.TypeOf(v)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	var fields []SourceField
+	p.addSourceFields(&fields, reflect.New(t), kinds, map[string]string{})
+	return fields
+}
+
+// Values returns all values of the named source kind, e.g. query,
+// in r. They're read as when picking, e.g. regardless of case if
+// SetCaseInsensitiveQuery is used. Returns nil for unknown kinds.
+func (p *Picker) Values(r *http.Request, kind, name string) []string {
+	read, found := p.readers[kind]
+	if !found {
+		return nil
+	}
+	return read(r, name)
+}
+
+func (p *Picker) addSourceFields(fields *[]SourceField, obj reflect.Value,
+	kinds []string, prefix map[string]string,
+) {
+	for i := 0; i < obj.Elem().NumField(); i++ {
+		field := p.field(obj, i)
+		if p.isNested(field) {
+			nested := obj.Elem().Field(i)
+			allocNested(nested)
+			p.addSourceFields(fields, nestedPtr(nested), kinds,
+				nestedPrefix(field, prefix, kinds),
+			)
+			continue
+		}
+		*fields = append(*fields, p.sourcesOf(field, kinds, prefix)...)
+	}
+}
+
+// nestedPrefix returns prefix of each kind followed by the source tag
+// of the nested struct field.
+func nestedPrefix(field reflect.StructField, prefix map[string]string,
+	kinds []string,
+) map[string]string {
+	nested := make(map[string]string)
+	for _, kind := range kinds {
+		nested[kind] = prefix[kind] + field.Tag.Get(kind)
+	}
+	return nested
+}
+
+// sourcesOf returns a source field of each kind field is tagged with.
+func (p *Picker) sourcesOf(field reflect.StructField, kinds []string,
+	prefix map[string]string,
+) []SourceField {
+	var fields []SourceField
+	for _, kind := range kinds {
+		if name, found := p.tagName(field, kind); found {
+			fields = append(fields, SourceField{
+				Kind: kind, Name: prefix[kind] + name, Field: field,
+			})
+		}
+	}
+	return fields
+}
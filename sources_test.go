@@ -0,0 +1,52 @@
+package xr
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExamplePicker_SourceFields() {
+	type Address struct {
+		Street string `query:"street"`
+	}
+	var x struct {
+		Page    int     `query:""`
+		Address Address `query:"address."`
+		Trace   string  `header:"x-trace"`
+	}
+	for _, f := range NewPicker().SourceFields(&x, "query") {
+		fmt.Println(f.Kind, f.Name, f.Field.Name)
+	}
+	// output:
+	// query page Page
+	// query address.street Street
+}
+
+func TestPicker_SourceFields_mapping(t *testing.T) {
+	type Item struct {
+		Id string
+	}
+	p := NewPicker()
+	p.UseMapping(Item{}, NewMapping().Field("Id", `path:"id"`))
+	got := p.SourceFields(Item{}, "path", "query")
+	if len(got) != 1 || got[0].Name != "id" || got[0].Field.Name != "Id" {
+		t.Errorf("got %+v", got)
+	}
+}
+
+func TestPicker_Values(t *testing.T) {
+	p := NewPicker()
+	p.SetCaseInsensitiveQuery(true)
+	r := httptest.NewRequest("GET", "/?ID=1&id=2&Id=3", http.NoBody)
+	if got := p.Values(r, "query", "id"); fmt.Sprint(got) != "[2]" {
+		t.Error("query", got)
+	}
+	if got := p.Values(r, "query", "iD"); len(got) != 3 {
+		t.Error("case insensitive", got)
+	}
+	if got := p.Values(r, "jibberish", "id"); got != nil {
+		t.Error("unknown kind", got)
+	}
+}
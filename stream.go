@@ -0,0 +1,123 @@
+package xr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// StreamNDJSON writes each value yielded by seq as a line of JSON
+// using PickerDefault, see StreamNDJSONWith.
+func StreamNDJSON[T any](
+	w http.ResponseWriter, seq func(func(T) bool),
+) error {
+	return StreamNDJSONWith(PickerDefault, w, seq)
+}
+
+// StreamNDJSONWith writes each value yielded by seq as a line of
+// JSON, flushing after each value. Values are encoded by the encoder
+// registered in p for application/json, see Picker.RegisterEncoder.
+// The shape of seq matches iter.Seq, see ChanSeq for streaming
+// channels. Writing stops on the first error which is returned.
+func StreamNDJSONWith[T any](p *Picker,
+	w http.ResponseWriter, seq func(func(T) bool),
+) error {
+	return p.stream(w, "application/x-ndjson", eachOf(seq), writeLine)
+}
+
+// StreamSSE writes each value yielded by seq as a server-sent event
+// using PickerDefault, see StreamSSEWith.
+func StreamSSE[T any](
+	w http.ResponseWriter, seq func(func(T) bool),
+) error {
+	return StreamSSEWith(PickerDefault, w, seq)
+}
+
+// StreamSSEWith writes each value yielded by seq as a server-sent
+// event with JSON data, encoded as by StreamNDJSONWith, flushing
+// after each event.
+func StreamSSEWith[T any](p *Picker,
+	w http.ResponseWriter, seq func(func(T) bool),
+) error {
+	w.Header().Set("cache-control", "no-cache")
+	return p.stream(w, "text/event-stream", eachOf(seq), writeEvent)
+}
+
+// ChanSeq returns a sequence of values received from ch until it is
+// closed.
+func ChanSeq[T any](ch <-chan T) func(func(T) bool) {
+	return func(yield func(T) bool) {
+		for v := range ch {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// eachOf returns seq as a sequence of any values.
+func eachOf[T any](seq func(func(T) bool)) func(func(any) bool) {
+	return func(yield func(any) bool) {
+		seq(func(v T) bool { return yield(v) })
+	}
+}
+
+// stream writes the encoded values of seq with write, flushing after
+// each value.
+func (p *Picker) stream(w http.ResponseWriter, ct string,
+	seq func(func(any) bool), write func(io.Writer, []byte) error,
+) error {
+	enc, err := p.encoderOf("application/json")
+	if err != nil {
+		return err
+	}
+	w.Header().Set("content-type", ct)
+	rc := http.NewResponseController(w)
+	seq(func(v any) bool {
+		if err = writeEncoded(w, enc, v, write); err != nil {
+			return false
+		}
+		err = flush(rc)
+		return err == nil
+	})
+	return err
+}
+
+// writeEncoded writes v encoded by enc, without trailing newlines,
+// with write.
+func writeEncoded(w io.Writer, enc func(io.Writer) Encoder, v any,
+	write func(io.Writer, []byte) error,
+) error {
+	var buf bytes.Buffer
+	if err := enc(&buf).Encode(v); err != nil {
+		return err
+	}
+	return write(w, bytes.TrimRight(buf.Bytes(), "\n"))
+}
+
+// flush ignores writers not supporting flushing.
+func flush(rc *http.ResponseController) error {
+	err := rc.Flush()
+	if errors.Is(err, http.ErrNotSupported) {
+		return nil
+	}
+	return err
+}
+
+func writeLine(w io.Writer, data []byte) error {
+	_, err := fmt.Fprintf(w, "%s\n", data)
+	return err
+}
+
+// writeEvent writes data as an event with one data field per line.
+func writeEvent(w io.Writer, data []byte) error {
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if _, err := fmt.Fprintf(w, "data: %s\n", line); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
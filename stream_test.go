@@ -0,0 +1,83 @@
+package xr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExampleStreamNDJSON() {
+	seq := func(yield func(Car) bool) {
+		_ = yield(Car{Sold: true}) && yield(Car{})
+	}
+	w := httptest.NewRecorder()
+	_ = StreamNDJSON(w, seq)
+	fmt.Print(w.Body.String())
+	// output:
+	// {"sold":true}
+	// {"sold":false}
+}
+
+func ExampleStreamSSE() {
+	ch := make(chan int, 2)
+	ch <- 1
+	ch <- 2
+	close(ch)
+	w := httptest.NewRecorder()
+	_ = StreamSSE(w, ChanSeq(ch))
+	fmt.Print(w.Body.String())
+	// output:
+	// data: 1
+	//
+	// data: 2
+}
+
+func TestStreamNDJSON_error(t *testing.T) {
+	ch := make(chan any, 3)
+	ch <- 1
+	ch <- func() {} // not encodable
+	ch <- 3
+	w := httptest.NewRecorder()
+	if err := StreamNDJSON(w, ChanSeq(ch)); err == nil || len(ch) != 1 {
+		t.Error("expect error stopping stream", err)
+	}
+	if ct := w.Header().Get("content-type"); ct != "application/x-ndjson" {
+		t.Error(ct)
+	}
+}
+
+func TestChanSeq_stop(t *testing.T) {
+	ch := make(chan int, 2)
+	ch <- 1
+	ch <- 2
+	close(ch)
+	var got []int
+	ChanSeq(ch)(func(v int) bool {
+		got = append(got, v)
+		return false
+	})
+	if len(got) != 1 {
+		t.Error(got)
+	}
+}
+
+func TestStreamSSEWith_encoder(t *testing.T) {
+	p := NewPicker()
+	w := httptest.NewRecorder()
+	seq := func(yield func(int) bool) { yield(1) }
+	if err := StreamSSEWith(p, w, seq); !errors.Is(err, ErrNotAcceptable) {
+		t.Error("expect ErrNotAcceptable, got", err)
+	}
+	p.RegisterEncoder("application/json", func(w io.Writer) Encoder {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", " ")
+		return enc
+	})
+	_ = StreamSSEWith(p, w, func(yield func([]int) bool) { yield([]int{1}) })
+	if got := w.Body.String(); got != "data: [\ndata:  1\ndata: ]\n\n" {
+		t.Errorf("%q", got)
+	}
+}
@@ -0,0 +1,74 @@
+package xr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// TextDecoder returns a decoder factory setting the string field
+// tagged `body:"text"`, declared or by a mapping of p, to the whole
+// body. Bodies larger than maxBytes result in ErrTooLarge. Register
+// it with
+//
+//	p.Register("text/plain", p.TextDecoder(DefaultTextBytes))
+func (p *Picker) TextDecoder(maxBytes int64) func(io.Reader) Decoder {
+	return func(r io.Reader) Decoder {
+		return decoderFunc(func(v any) error {
+			return p.decodeText(r, v, maxBytes)
+		})
+	}
+}
+
+// DefaultTextBytes is the max size of text bodies decoded by
+// PickerDefault.
+const DefaultTextBytes = 1 << 20
+
+func (p *Picker) decodeText(r io.Reader, v any, maxBytes int64) error {
+	field := p.bodyField(v, "text")
+	if !field.IsValid() {
+		return nil
+	}
+	if field.Kind() != reflect.String {
+		return unsupportedBody("text", field)
+	}
+	data, err := readAll(r, maxBytes)
+	if err != nil {
+		return err
+	}
+	field.SetString(string(data))
+	return nil
+}
+
+// bodyField returns the field of struct pointer v tagged with
+// `body:"<name>"`, with tags from a mapping if any. The returned
+// value is invalid if there is no such field.
+func (p *Picker) bodyField(v any, name string) reflect.Value {
+	obj := reflect.ValueOf(v)
+	for i := 0; i < obj.Elem().NumField(); i++ {
+		if p.field(obj, i).Tag.Get("body") == name {
+			return obj.Elem().Field(i)
+		}
+	}
+	return reflect.Value{}
+}
+
+func unsupportedBody(name string, field reflect.Value) error {
+	return fmt.Errorf("body %s %v: unsupported", name, field.Type())
+}
+
+// readAll reads at most maxBytes from r.
+func readAll(r io.Reader, maxBytes int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, fmt.Errorf("%w: max %d bytes", ErrTooLarge, maxBytes)
+	}
+	return data, nil
+}
+
+// ErrTooLarge is returned when a body exceeds a max size.
+var ErrTooLarge = errors.New("too large")
@@ -0,0 +1,74 @@
+package xr
+
+import (
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func ExamplePicker_TextDecoder() {
+	var x struct {
+		Event   string `header:"x-event"`
+		Message string `body:"text"`
+	}
+	body := strings.NewReader("disk almost full")
+	r := httptest.NewRequest("POST", "/hook", body)
+	r.Header.Set("content-type", "text/plain")
+	r.Header.Set("x-event", "alert")
+	_ = Pick(&x, r)
+	fmt.Printf("%s: %s", x.Event, x.Message)
+	// output:
+	// alert: disk almost full
+}
+
+func TestPicker_TextDecoder_tooLarge(t *testing.T) {
+	p := NewPicker()
+	p.Register("text/plain", p.TextDecoder(3))
+	var x struct {
+		Message string `body:"text"`
+	}
+	r := httptest.NewRequest("POST", "/", strings.NewReader("hello"))
+	r.Header.Set("content-type", "text/plain")
+	if err := p.Pick(&x, r); !errors.Is(err, ErrTooLarge) {
+		t.Error("expect ErrTooLarge, got", err)
+	}
+}
+
+func TestPicker_TextDecoder_unsupported(t *testing.T) {
+	var x struct {
+		Message []int `body:"text"`
+	}
+	r := httptest.NewRequest("POST", "/", strings.NewReader("hello"))
+	r.Header.Set("content-type", "text/plain")
+	if err := Pick(&x, r); err == nil {
+		t.Error("expect error")
+	}
+}
+
+func TestPicker_TextDecoder_noField(t *testing.T) {
+	var x struct {
+		Message string
+	}
+	r := httptest.NewRequest("POST", "/", strings.NewReader("hello"))
+	r.Header.Set("content-type", "text/plain")
+	if err := Pick(&x, r); err != nil || x.Message != "" {
+		t.Error(x, err)
+	}
+}
+
+func TestPicker_TextDecoder_mapping(t *testing.T) {
+	type Hook struct {
+		Message string
+	}
+	p := NewPicker()
+	p.Register("text/plain", p.TextDecoder(10))
+	p.UseMapping(Hook{}, NewMapping().Field("Message", `body:"text"`))
+	var x Hook
+	r := httptest.NewRequest("POST", "/", strings.NewReader("hello"))
+	r.Header.Set("content-type", "text/plain")
+	if err := p.Pick(&x, r); err != nil || x.Message != "hello" {
+		t.Error(x, err)
+	}
+}
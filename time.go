@@ -0,0 +1,32 @@
+package xr
+
+import (
+	"reflect"
+	"time"
+)
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// convertTime returns v, in the layout of field tag layout, as
+// RFC3339. Without a layout tag time.Time fields are set from RFC3339
+// values, e.g. `query:"since" layout:"2006-01-02"`.
+func convertTime(field reflect.StructField, v string) (string, error) {
+	layout, found := field.Tag.Lookup("layout")
+	if !found || field.Type != timeType {
+		return v, nil
+	}
+	t, err := time.Parse(layout, v)
+	if err != nil {
+		return "", err
+	}
+	return t.Format(time.RFC3339Nano), nil
+}
+
+func setTimeField(field reflect.Value, v string) error {
+	t, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil {
+		return err
+	}
+	field.Set(reflect.ValueOf(t))
+	return nil
+}
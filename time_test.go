@@ -0,0 +1,51 @@
+package xr
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func ExamplePick_time() {
+	var x struct {
+		Since time.Time `query:"since" layout:"2006-01-02"`
+		Until time.Time `query:"until"`
+	}
+	r := httptest.NewRequest(
+		"GET", "/?since=2024-09-01&until=2024-09-30T12:00:00%2B02:00", nil,
+	)
+	_ = Pick(&x, r)
+	fmt.Println(x.Since)
+	fmt.Println(x.Until)
+	// output:
+	// 2024-09-01 00:00:00 +0000 UTC
+	// 2024-09-30 12:00:00 +0200 +0200
+}
+
+func TestPick_timeInvalid(t *testing.T) {
+	var x struct {
+		Since time.Time `query:"since" layout:"2006-01-02"`
+		Until time.Time `query:"until"`
+	}
+	for _, q := range []string{"since=01/09/2024", "until=2024-09-01"} {
+		r := httptest.NewRequest("GET", "/?"+q, nil)
+		if err := Pick(&x, r); err == nil {
+			t.Error("expect error for", q)
+		}
+	}
+}
+
+func TestPick_timeSetter(t *testing.T) {
+	p := NewPicker()
+	UseSetterFor(p, func(v string) (time.Time, error) {
+		return time.Unix(0, 0).UTC(), nil
+	})
+	var x struct {
+		Since time.Time `query:"since"`
+	}
+	r := httptest.NewRequest("GET", "/?since=epoch", nil)
+	if err := p.Pick(&x, r); err != nil || x.Since.Unix() != 0 {
+		t.Error(x.Since, err)
+	}
+}
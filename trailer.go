@@ -0,0 +1,51 @@
+package xr
+
+import (
+	"io"
+	"net/http"
+	"reflect"
+)
+
+// readTrailer returns values of the trailer with the given name, e.g.
+// `trailer:"X-Checksum"`. The body is drained before fields are
+// picked, see drainForTrailer.
+func readTrailer(r *http.Request, name string) []string {
+	return r.Trailer.Values(name)
+}
+
+// drainForTrailer discards what remains of the body after decoding
+// if dst has fields tagged trailer, as trailers are only known once
+// the body is read to the end. Fields tagged trailer can therefore
+// not be combined with fields streaming the body, see Check.
+func (p *Picker) drainForTrailer(dst any, r *http.Request) {
+	if r.Body != nil && p.hasTrailer(dst) {
+		_, _ = io.Copy(io.Discard, r.Body)
+	}
+}
+
+// hasTrailer returns true if struct pointer dst has fields tagged
+// trailer, nested ones included. Results are cached by type.
+func (p *Picker) hasTrailer(dst any) bool {
+	t := reflect.TypeOf(dst)
+	if found, cached := p.trailerTypes.Load(t); cached {
+		return found.(bool)
+	}
+	found := len(p.SourceFields(dst, "trailer")) > 0
+	p.trailerTypes.Store(t, found)
+	return found
+}
+
+// isStreaming returns true if field gets a reader of the body, i.e.
+// io.Reader fields tagged `body:""` or `body:"binary"` and fields
+// tagged part of type io.Reader or *multipart.Part.
+func isStreaming(field reflect.StructField) bool {
+	if _, found := field.Tag.Lookup("part"); found {
+		return field.Type == readerType || field.Type == partType
+	}
+	body, found := field.Tag.Lookup("body")
+	return found && streamedBodies[body] && field.Type == readerType
+}
+
+// streamedBodies are the names of body tags of fields set to a
+// reader of the body
+var streamedBodies = map[string]bool{"": true, "binary": true}
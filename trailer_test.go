@@ -0,0 +1,84 @@
+package xr
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func ExamplePick_trailer() {
+	var x struct {
+		Name     string `json:"name"`
+		Checksum string `trailer:"X-Checksum" required:"true"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Println(Pick(&x, r), x.Name, x.Checksum)
+		},
+	))
+	defer srv.Close()
+
+	body := strings.NewReader(`{"name": "john"}`)
+	r, _ := http.NewRequest("POST", srv.URL, body)
+	r.Header.Set("content-type", "application/json")
+	r.ContentLength = -1 // chunked, allowing trailers
+	r.Trailer = http.Header{"X-Checksum": {"8f3a"}}
+	resp, err := http.DefaultClient.Do(r)
+	if err == nil {
+		resp.Body.Close()
+	}
+	// output:
+	// <nil> john 8f3a
+}
+
+func Test_readTrailer_missing(t *testing.T) {
+	var x struct {
+		Checksum string `trailer:"X-Checksum" required:"true"`
+	}
+	r := httptest.NewRequest("POST", "/", strings.NewReader("..."))
+	if err := Pick(&x, r); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestCheck_trailerWithStream(t *testing.T) {
+	var x struct {
+		Data     io.Reader `body:"binary"`
+		Checksum string    `trailer:"X-Checksum"`
+	}
+	err := NewPicker().Check(&x)
+	exp := "Data: streaming body with trailer X-Checksum"
+	if err == nil || err.Error() != exp {
+		t.Errorf("got %v, exp %s", err, exp)
+	}
+	var y struct {
+		Data []byte `body:"binary"`
+		Sum  string `trailer:"X-Checksum"`
+	}
+	if err := NewPicker().Check(&y); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPick_trailers(t *testing.T) {
+	var x struct {
+		Name string `json:"name"`
+		Sum  string `trailer:"X-Checksum"`
+		Algo string `trailer:"X-Algorithm"`
+	}
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"name":"a"}`))
+	r.Header.Set("content-type", "application/json")
+	r.Trailer = http.Header{"X-Checksum": {"8f3a"}, "X-Algorithm": {"crc"}}
+	if err := Pick(&x, r); err != nil {
+		t.Fatal(err)
+	}
+	if got := x.Name + " " + x.Sum + " " + x.Algo; got != "a 8f3a crc" {
+		t.Error(got)
+	}
+	if n, _ := r.Body.Read(make([]byte, 1)); n != 0 {
+		t.Error("body not drained")
+	}
+}
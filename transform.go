@@ -0,0 +1,37 @@
+package xr
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// transform returns v with the comma separated transforms of field
+// tag transform applied in order, e.g. `transform:"trim,lower"`.
+func transform(field reflect.StructField, v string) (string, error) {
+	names, found := field.Tag.Lookup("transform")
+	if !found {
+		return v, nil
+	}
+	return applyTransforms(names, v)
+}
+
+// applyTransforms returns v transformed by the comma separated
+// names.
+func applyTransforms(names, v string) (string, error) {
+	for _, name := range strings.Split(names, ",") {
+		fn, found := transforms[name]
+		if !found {
+			return "", fmt.Errorf("transform %s: unknown", name)
+		}
+		v = fn(v)
+	}
+	return v, nil
+}
+
+// transforms of source values by name
+var transforms = map[string]func(string) string{
+	"trim":  strings.TrimSpace,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
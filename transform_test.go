@@ -0,0 +1,32 @@
+package xr
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExamplePick_transform() {
+	var x struct {
+		Email string `query:"email" transform:"trim,lower" format:"email"`
+	}
+	r := httptest.NewRequest("GET", "/?email=+John@Example.COM+", http.NoBody)
+	_ = Pick(&x, r)
+	fmt.Println(x.Email)
+	// output:
+	// john@example.com
+}
+
+func Test_transform_unknown(t *testing.T) {
+	var x struct {
+		Slug string `query:"slug" transform:"trim,kebab"`
+	}
+	r := httptest.NewRequest("GET", "/?slug=a", http.NoBody)
+	if err := Pick(&x, r); err == nil {
+		t.Error("expected error")
+	}
+	if err := Check(&x); err == nil {
+		t.Error("Check: expected error")
+	}
+}
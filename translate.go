@@ -0,0 +1,98 @@
+package xr
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+// Translator renders messages of error codes, see ErrorCode, in one
+// of the languages of a request.
+type Translator interface {
+	// Translate returns the message of code with params in the
+	// first possible of langs, e.g. "sv-se" or "en", ordered by
+	// preference. It returns false if there is no such message.
+	Translate(langs []string, code string, params []any) (string, bool)
+}
+
+// SetTranslator sets the translator of messages of errors written by
+// Handler, using languages of the accept-language header. Messages
+// of field tag errmsg are kept. By default messages are not
+// translated.
+func (p *Picker) SetTranslator(t Translator) {
+	p.translator = t
+}
+
+// Translate sets the message of each *PickError in err, joined
+// errors included, translated to a language accepted by r, see
+// SetTranslator.
+func (p *Picker) Translate(r *http.Request, err error) {
+	if p.translator == nil {
+		return
+	}
+	langs := acceptedLanguages(r)
+	for _, e := range pickErrors(err) {
+		msg, ok := p.translator.Translate(langs, e.Code, e.Params)
+		if ok && e.Message == "" {
+			e.Message = msg
+		}
+	}
+}
+
+// acceptedLanguages returns languages of the accept-language header
+// of r in order of preference, in lower case.
+func acceptedLanguages(r *http.Request) []string {
+	ranges := parseAccept(r.Header.Values("accept-language"))
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].q > ranges[j].q
+	})
+	var langs []string
+	for _, lr := range ranges {
+		if lr.q > 0 && lr.mediaType != "*" && lr.mediaType != "*/*" {
+			langs = append(langs, lr.mediaType)
+		}
+	}
+	return langs
+}
+
+// ErrorCode returns a code of err, e.g. required, and its
+// parameters. Failed checks of field tags have the tag as code and
+// its argument as parameter, e.g. minimum and "18". Numbers and bools
+// that can't be parsed have code parse_int, parse_float or
+// parse_bool with the value as parameter. Other errors are invalid.
+func ErrorCode(err error) (string, []any) {
+	var ce *checkError
+	if errors.As(err, &ce) {
+		return ce.tag, []any{ce.arg}
+	}
+	var ne *strconv.NumError
+	if errors.As(err, &ne) {
+		return parseCodes[ne.Func], []any{ne.Num}
+	}
+	for _, c := range errorCodes {
+		if errors.Is(err, c.err) {
+			return c.code, nil
+		}
+	}
+	return "invalid", nil
+}
+
+// parseCodes map strconv funcs to error codes
+var parseCodes = map[string]string{
+	"ParseInt":   "parse_int",
+	"ParseUint":  "parse_int",
+	"ParseFloat": "parse_float",
+	"ParseBool":  "parse_bool",
+}
+
+// errorCodes of errors, checked in order
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	{ErrRequired, "required"},
+	{ErrAmbiguous, "ambiguous"},
+	{ErrMalformedAuth, "malformed_auth"},
+	{ErrTooLarge, "too_large"},
+}
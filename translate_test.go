@@ -0,0 +1,71 @@
+package xr
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// messages by language and code
+type messages map[string]map[string]string
+
+func (m messages) Translate(langs []string, code string, params []any) (
+	string, bool,
+) {
+	for _, lang := range langs {
+		if format, found := m[lang][code]; found {
+			return fmt.Sprintf(format, params...), true
+		}
+	}
+	return "", false
+}
+
+func ExamplePicker_SetTranslator() {
+	p := NewPicker()
+	p.SetTranslator(messages{
+		"sv": {"minimum": "måste vara minst %s"},
+	})
+	h := p.Handler(func(w http.ResponseWriter, r *http.Request) error {
+		var x struct {
+			Age int `query:"age" minimum:"18"`
+		}
+		return p.Pick(&x, r)
+	})
+	r := httptest.NewRequest("GET", "/?age=12", http.NoBody)
+	r.Header.Set("Accept-Language", "en;q=0.5, sv")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	fmt.Print(w.Body)
+	// output:
+	// {"error":"måste vara minst 18"}
+}
+
+func TestErrorCode(t *testing.T) {
+	_, err := strconv.ParseInt("x", 10, 64)
+	cases := []struct {
+		err  error
+		code string
+	}{
+		{err, "parse_int"},
+		{ErrRequired, "required"},
+		{fmt.Errorf("%w", ErrTooLarge), "too_large"},
+		{&checkError{tag: "enum", err: ErrAmbiguous}, "enum"},
+		{fmt.Errorf("other"), "invalid"},
+	}
+	for _, c := range cases {
+		if code, _ := ErrorCode(c.err); code != c.code {
+			t.Errorf("%v: got %s, expected %s", c.err, code, c.code)
+		}
+	}
+}
+
+func Test_acceptedLanguages(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", http.NoBody)
+	r.Header.Set("Accept-Language", "de;q=0.2, sv-SE, *;q=0.1, en;q=0")
+	got := fmt.Sprint(acceptedLanguages(r))
+	if got != "[sv-se de]" {
+		t.Error(got)
+	}
+}
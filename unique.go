@@ -0,0 +1,47 @@
+package xr
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// SetUniqueHeaders controls if picking a header given multiple times
+// with different values fails with ErrAmbiguous. Use field tag
+// `unique:"true"` to enable it for individual fields.
+func (p *Picker) SetUniqueHeaders(v bool) {
+	p.unique["header"] = v
+}
+
+// SetUniqueQuery controls if picking a query parameter given
+// multiple times with different values fails with ErrAmbiguous. Use
+// field tag `unique:"true"` to enable it for individual fields.
+func (p *Picker) SetUniqueQuery(v bool) {
+	p.unique["query"] = v
+}
+
+// ErrAmbiguous is the cause when a value must be unique.
+var ErrAmbiguous = errors.New("ambiguous")
+
+// checkUnique returns ErrAmbiguous if all values are not equal and
+// the field or source requires it to be unique.
+func (p *Picker) checkUnique(field reflect.StructField, v value) error {
+	if !p.requireUnique(field, v.kind) {
+		return nil
+	}
+	for _, other := range v.all[1:] {
+		if other != v.all[0] {
+			return fmt.Errorf("%w: %q", ErrAmbiguous, v.all)
+		}
+	}
+	return nil
+}
+
+func (p *Picker) requireUnique(field reflect.StructField, kind string) bool {
+	if arg, found := field.Tag.Lookup("unique"); found {
+		on, _ := strconv.ParseBool(arg)
+		return on
+	}
+	return p.unique[kind]
+}
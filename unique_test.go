@@ -0,0 +1,81 @@
+package xr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExamplePicker_SetUniqueHeaders() {
+	p := NewPicker()
+	p.SetUniqueHeaders(true)
+
+	var x struct {
+		Auth string `header:"authorization"`
+	}
+	r := httptest.NewRequest("GET", "/", http.NoBody)
+	r.Header.Add("authorization", "Bearer A")
+	r.Header.Add("authorization", "Bearer B")
+	fmt.Println(p.Pick(&x, r))
+	// output:
+	// pick Auth from header[authorization]: ambiguous: ["Bearer A" "Bearer B"]
+}
+
+func TestPick_uniqueTag(t *testing.T) {
+	var x struct {
+		Auth  string `header:"authorization" unique:"true"`
+		Color string `header:"color"`
+	}
+	r := httptest.NewRequest("GET", "/", http.NoBody)
+	r.Header.Add("color", "red")
+	r.Header.Add("color", "blue")
+	r.Header.Add("authorization", "A")
+	r.Header.Add("authorization", "A")
+	if err := Pick(&x, r); err != nil {
+		t.Error(err)
+	}
+	r.Header.Add("authorization", "B")
+	if err := Pick(&x, r); !errors.Is(err, ErrAmbiguous) {
+		t.Error("expect ErrAmbiguous, got", err)
+	}
+}
+
+func TestPicker_SetUniqueHeaders_optOut(t *testing.T) {
+	p := NewPicker()
+	p.SetUniqueHeaders(true)
+	var x struct {
+		Accept string `header:"accept" unique:"false"`
+	}
+	r := httptest.NewRequest("GET", "/", http.NoBody)
+	r.Header.Add("accept", "text/html")
+	r.Header.Add("accept", "text/plain")
+	if err := p.Pick(&x, r); err != nil {
+		t.Error(err)
+	}
+}
+
+func ExamplePicker_SetUniqueQuery() {
+	p := NewPicker()
+	p.SetUniqueQuery(true)
+
+	var x struct {
+		Id   string `query:"id"`
+		Sort string `query:"sort"`
+	}
+	r := httptest.NewRequest("GET", "/?sort=asc&sort=asc&id=1&id=2", nil)
+	fmt.Println(p.Pick(&x, r))
+	// output:
+	// pick Id from query[id]: ambiguous: ["1" "2"]
+}
+
+func TestPick_uniqueTag_query(t *testing.T) {
+	var x struct {
+		Id string `query:"id" unique:"true"`
+	}
+	r := httptest.NewRequest("GET", "/?id=1&id=2", http.NoBody)
+	if err := Pick(&x, r); !errors.Is(err, ErrAmbiguous) {
+		t.Error("expect ErrAmbiguous, got", err)
+	}
+}
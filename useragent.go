@@ -0,0 +1,100 @@
+package xr
+
+import (
+	"reflect"
+	"strings"
+)
+
+// UserAgent is set from a User-Agent header value, e.g.
+//
+//	Agent UserAgent `header:"User-Agent"`
+//
+// Parsing is done by [ParseUserAgent] unless configured otherwise
+// using [Picker.SetUserAgentParser].
+type UserAgent struct {
+	// Raw header value
+	Raw string
+
+	// Product and Version of the most specific product token,
+	// e.g. Chrome and 120.0.0.0
+	Product string
+	Version string
+
+	// OS hint from the first comment, e.g. Windows NT 10.0
+	OS string
+
+	// Mobile is true if the value mentions Mobile
+	Mobile bool
+}
+
+func (ua UserAgent) String() string {
+	return ua.Raw
+}
+
+// ParseUserAgent returns a best effort parsing of the given
+// User-Agent header value.
+func ParseUserAgent(v string) UserAgent {
+	comments, tokens := splitUserAgent(v)
+	ua := UserAgent{
+		Raw:    v,
+		Mobile: strings.Contains(v, "Mobile"),
+	}
+	if len(comments) > 0 {
+		os, _, _ := strings.Cut(comments[0], ";")
+		ua.OS = strings.TrimSpace(os)
+	}
+	ua.Product, ua.Version = mostSpecific(tokens)
+	return ua
+}
+
+// splitUserAgent returns the parenthesized comments and the
+// product tokens of v.
+func splitUserAgent(v string) (comments, tokens []string) {
+	for v != "" {
+		before, after, _ := strings.Cut(v, "(")
+		tokens = append(tokens, strings.Fields(before)...)
+		comment, rest, _ := strings.Cut(after, ")")
+		if comment != "" {
+			comments = append(comments, comment)
+		}
+		v = rest
+	}
+	return
+}
+
+// mostSpecific returns name and version of the first known product
+// found in tokens. If none is known the first token is used.
+func mostSpecific(tokens []string) (string, string) {
+	products := make(map[string]string)
+	for _, t := range tokens {
+		name, version, _ := strings.Cut(t, "/")
+		products[name] = version
+	}
+	for _, name := range knownProducts {
+		if version, found := products[name]; found {
+			return name, version
+		}
+	}
+	if len(tokens) == 0 {
+		return "", ""
+	}
+	name, version, _ := strings.Cut(tokens[0], "/")
+	return name, version
+}
+
+// knownProducts in order of specificity, e.g. Edge also mentions
+// Chrome and Safari.
+var knownProducts = []string{
+	"Edg", "OPR", "SamsungBrowser", "Firefox", "Chrome", "Safari",
+}
+
+// SetUserAgentParser replaces the func used to parse fields of
+// type UserAgent.
+func (p *Picker) SetUserAgentParser(fn func(string) UserAgent) {
+	p.parseUserAgent = fn
+}
+
+func (p *Picker) setUserAgent(field reflect.Value, v string) error {
+	field.Set(reflect.ValueOf(p.parseUserAgent(v)))
+	return nil
+}
@@ -0,0 +1,71 @@
+package xr
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExamplePick_userAgent() {
+	var x struct {
+		Agent UserAgent `header:"User-Agent"`
+	}
+	r := httptest.NewRequest("GET", "/", http.NoBody)
+	r.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) "+
+		"AppleWebKit/537.36 (KHTML, like Gecko) "+
+		"Chrome/120.0.0.0 Safari/537.36")
+	_ = Pick(&x, r)
+	fmt.Println(x.Agent.Product, x.Agent.Version)
+	fmt.Println(x.Agent.OS)
+	// output:
+	// Chrome 120.0.0.0
+	// Windows NT 10.0
+}
+
+func TestParseUserAgent(t *testing.T) {
+	cases := []struct {
+		v, product, version, os string
+		mobile                  bool
+	}{
+		{"curl/8.4.0", "curl", "8.4.0", "", false},
+		{"", "", "", "", false},
+		{
+			"Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) " +
+				"AppleWebKit/605.1.15 (KHTML, like Gecko) " +
+				"Version/17.0 Mobile/15E148 Safari/604.1",
+			"Safari", "604.1", "iPhone", true,
+		},
+		{
+			"Mozilla/5.0 (X11; Linux x86_64; rv:121.0) " +
+				"Gecko/20100101 Firefox/121.0",
+			"Firefox", "121.0", "X11", false,
+		},
+	}
+	for _, c := range cases {
+		ua := ParseUserAgent(c.v)
+		got := fmt.Sprint(ua.Product, ua.Version, ua.OS, ua.Mobile)
+		exp := fmt.Sprint(c.product, c.version, c.os, c.mobile)
+		if got != exp {
+			t.Errorf("%q\ngot %s\nexp %s", c.v, got, exp)
+		}
+	}
+}
+
+func TestPicker_SetUserAgentParser(t *testing.T) {
+	p := NewPicker()
+	p.SetUserAgentParser(func(v string) UserAgent {
+		return UserAgent{Raw: v, Product: "bot"}
+	})
+	var x struct {
+		Agent UserAgent `header:"User-Agent"`
+	}
+	r := httptest.NewRequest("GET", "/", http.NoBody)
+	r.Header.Set("User-Agent", "curl/8.4.0")
+	if err := p.Pick(&x, r); err != nil {
+		t.Fatal(err)
+	}
+	if x.Agent.Product != "bot" {
+		t.Error("parser not used", x.Agent)
+	}
+}
@@ -0,0 +1,37 @@
+package xr
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"unicode/utf8"
+)
+
+// SetValidUTF8 controls if picking values with invalid UTF-8
+// sequences fails with ErrInvalidUTF8. Use field tag `utf8:"true"`
+// to enable it for individual fields.
+func (p *Picker) SetValidUTF8(v bool) {
+	p.validUTF8 = v
+}
+
+// ErrInvalidUTF8 is the cause when a value must be valid UTF-8.
+var ErrInvalidUTF8 = errors.New("invalid UTF-8")
+
+// checkUTF8 returns v as is, or ErrInvalidUTF8 if required and v is
+// not valid UTF-8.
+func (p *Picker) checkUTF8(field reflect.StructField, v string) (
+	string, error,
+) {
+	if p.requireUTF8(field) && !utf8.ValidString(v) {
+		return "", ErrInvalidUTF8
+	}
+	return v, nil
+}
+
+func (p *Picker) requireUTF8(field reflect.StructField) bool {
+	if arg, found := field.Tag.Lookup("utf8"); found {
+		on, _ := strconv.ParseBool(arg)
+		return on
+	}
+	return p.validUTF8
+}
@@ -0,0 +1,43 @@
+package xr
+
+import (
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExamplePicker_SetValidUTF8() {
+	p := NewPicker()
+	p.SetValidUTF8(true)
+	var x struct {
+		Name string `header:"x-name"`
+	}
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("x-name", "caf\xe9")
+	fmt.Println(p.Pick(&x, r))
+	// output:
+	// pick Name from header[x-name]: invalid UTF-8
+}
+
+func TestPicker_utf8Tag(t *testing.T) {
+	var x struct {
+		Name string `query:"name" utf8:"true"`
+	}
+	r := httptest.NewRequest("GET", "/?name=caf%E9", nil)
+	if err := Pick(&x, r); !errors.Is(err, ErrInvalidUTF8) {
+		t.Error("expect ErrInvalidUTF8, got", err)
+	}
+}
+
+func TestPicker_utf8TagOff(t *testing.T) {
+	p := NewPicker()
+	p.SetValidUTF8(true)
+	var x struct {
+		Name string `query:"name" utf8:"false"`
+	}
+	r := httptest.NewRequest("GET", "/?name=caf%E9", nil)
+	if err := p.Pick(&x, r); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,165 @@
+package xr
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// RegisterFormat adds a named format used by field tag format,
+// e.g. `format:"iban"`. Empty values are not checked. Safe to call
+// while picking. Panics if the name is already registered.
+// Registered formats replace built in ones with the same name, i.e.
+// email, uuid, ipv4, ipv6, hostname, uri, date and date-time.
+func (p *Picker) RegisterFormat(name string, fn func(string) error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, found := p.formats[name]; found {
+		panic(fmt.Sprintf("RegisterFormat(%q): already exists", name))
+	}
+	p.formats[name] = fn
+}
+
+// check validates a field value against the argument of the field
+// tag with the same name.
+type check struct {
+	tag string
+	fn  func(field reflect.Value, arg string) error
+}
+
+// validate applies all checks with a matching tag and the validator
+// of the type on field i of obj.
+func (p *Picker) validate(obj reflect.Value, i int, src source) error {
+	field := p.field(obj, i)
+	v := obj.Elem().Field(i)
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		// nothing to check
+		return nil
+	}
+	if err := p.checkField(field, reflect.Indirect(v)); err != nil {
+		return NewPickError(field.Name, src.String(), err)
+	}
+	return nil
+}
+
+// CheckValue applies the checks of tag on v, e.g. a string and
+// `minLength:"3" pattern:"^[a-z]+$"`. Use it to validate values
+// picked by other means. The error has the code and parameters of
+// the failed check, see ErrorCode.
+func (p *Picker) CheckValue(v any, tag reflect.StructTag) error {
+	return p.checkField(reflect.StructField{Tag: tag}, reflect.ValueOf(v))
+}
+
+func (p *Picker) checkField(field reflect.StructField, v reflect.Value) error {
+	for _, c := range p.checksOf(field.Tag) {
+		if err := c.fn(v, c.arg); err != nil {
+			return &checkError{tag: c.tag, arg: c.arg, err: err}
+		}
+	}
+	return p.checkType(v)
+}
+
+// checksOf returns the checks found in tag with their arguments.
+// Results are cached by tag.
+func (p *Picker) checksOf(tag reflect.StructTag) []tagCheck {
+	if cached, found := p.tagChecks.Load(tag); found {
+		return cached.([]tagCheck)
+	}
+	var checks []tagCheck
+	for _, c := range p.checks {
+		if arg, found := checkArg(tag, c.tag); found {
+			checks = append(checks, tagCheck{c.tag, c.fn, arg})
+		}
+	}
+	p.tagChecks.Store(tag, checks)
+	return checks
+}
+
+// tagCheck is a check with the argument of a field tag.
+type tagCheck struct {
+	tag string
+	fn  func(field reflect.Value, arg string) error
+	arg string
+}
+
+// checkError is a failed check of a field tag with its argument,
+// the code and parameters of localized messages.
+type checkError struct {
+	tag, arg string
+	err      error
+}
+
+func (e *checkError) Error() string { return e.err.Error() }
+func (e *checkError) Unwrap() error { return e.err }
+
+// checkType applies the validator registered for the type of v.
+func (p *Picker) checkType(v reflect.Value) error {
+	fn, found := lookup(p, p.validators, v.Type())
+	if !found || !v.CanInterface() {
+		return nil
+	}
+	return fn(v)
+}
+
+// UseValidatorFor registers fn for validating all fields of type T
+// after they are set, e.g.
+//
+//	UseValidatorFor(p, func(m Money) error { ... })
+//
+// Safe to call while picking. Panics if a validator for T already
+// exists.
+func UseValidatorFor[T any](p *Picker, fn func(T) error) {
+	typ := reflect.TypeFor[T]()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, found := p.validators[typ]; found {
+		panic(fmt.Sprintf("UseValidatorFor(%v): already exists", typ))
+	}
+	p.validators[typ] = func(v reflect.Value) error {
+		return fn(v.Interface().(T))
+	}
+}
+
+// format returns the registered, or built in, format of name.
+func (p *Picker) format(name string) (func(string) error, bool) {
+	if fn, found := lookup(p, p.formats, name); found {
+		return fn, true
+	}
+	fn, found := formats[name]
+	return fn, found
+}
+
+func (p *Picker) checkFormat(field reflect.Value, name string) error {
+	if field.Kind() != reflect.String {
+		return fmt.Errorf("format %v: unsupported", field.Kind())
+	}
+	fn, found := p.format(name)
+	if !found {
+		return fmt.Errorf("format %s: unknown", name)
+	}
+	if field.String() == "" {
+		return nil
+	}
+	if err := fn(field.String()); err != nil {
+		return fmt.Errorf("format %s: %w", name, err)
+	}
+	return nil
+}
+
+func checkNotBlank(field reflect.Value, arg string) error {
+	on, err := strconv.ParseBool(arg)
+	if err != nil || !on {
+		return err
+	}
+	if field.Kind() != reflect.String {
+		return fmt.Errorf("notBlank %v: unsupported", field.Kind())
+	}
+	if strings.TrimSpace(field.String()) == "" {
+		return errBlank
+	}
+	return nil
+}
+
+var errBlank = errors.New("blank")
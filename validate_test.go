@@ -0,0 +1,188 @@
+package xr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func ExamplePicker_RegisterFormat() {
+	p := NewPicker()
+	p.RegisterFormat("iban", func(v string) error {
+		if !strings.HasPrefix(v, "SE") {
+			return errors.New("only swedish accounts")
+		}
+		return nil
+	})
+
+	var x struct {
+		Account string `query:"account" format:"iban"`
+	}
+	r := httptest.NewRequest("GET", "/?account=DE89370400440532013000", nil)
+	fmt.Println(p.Pick(&x, r))
+	// output:
+	// pick Account from query[account]: format iban: only swedish accounts
+}
+
+func TestPicker_RegisterFormat_duplicate(t *testing.T) {
+	defer catchPanic(t)
+	p := NewPicker()
+	fn := func(string) error { return nil }
+	p.RegisterFormat("x", fn)
+	p.RegisterFormat("x", fn)
+}
+
+func TestPicker_checkFormat(t *testing.T) {
+	p := NewPicker()
+	p.RegisterFormat("word", func(v string) error {
+		if strings.Contains(v, " ") {
+			return errors.New("space")
+		}
+		return nil
+	})
+	r := httptest.NewRequest("GET", "/?a=one", http.NoBody)
+	{ // unknown format
+		var x struct {
+			A string `query:"a" format:"jibberish"`
+		}
+		if err := p.Pick(&x, r); err == nil {
+			t.Error("expect error")
+		}
+	}
+	{ // only strings
+		var x struct {
+			A int `query:"n" format:"word"`
+		}
+		r := httptest.NewRequest("GET", "/?n=1", http.NoBody)
+		if err := p.Pick(&x, r); err == nil {
+			t.Error("expect error")
+		}
+	}
+}
+
+func TestPicker_checkFormat_body(t *testing.T) {
+	p := NewPicker()
+	p.Register("application/json", func(r io.Reader) Decoder {
+		return json.NewDecoder(r)
+	})
+	p.RegisterFormat("word", func(v string) error {
+		if strings.Contains(v, " ") {
+			return errors.New("space")
+		}
+		return nil
+	})
+	{
+		var x struct {
+			A string `json:"a" format:"word"`
+		}
+		body := strings.NewReader(`{"a":"two words"}`)
+		r := httptest.NewRequest("POST", "/", body)
+		r.Header.Set("content-type", "application/json")
+		if err := p.Pick(&x, r); err == nil {
+			t.Error("expect error")
+		}
+		// empty values are not checked
+		x.A = ""
+		body = strings.NewReader(`{}`)
+		r = httptest.NewRequest("POST", "/", body)
+		r.Header.Set("content-type", "application/json")
+		if err := p.Pick(&x, r); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func ExamplePick_notBlank() {
+	var x struct {
+		Name string `form:"name" notBlank:"true"`
+	}
+	body := strings.NewReader("name=+++")
+	r := httptest.NewRequest("POST", "/", body)
+	r.Header.Set("content-type", "application/x-www-form-urlencoded")
+	fmt.Println(Pick(&x, r))
+	// output:
+	// pick Name from form[name]: blank
+}
+
+func Test_checkNotBlank(t *testing.T) {
+	var x struct {
+		Name string `query:"name" notBlank:"true"`
+		Any  string `query:"any" notBlank:"false"`
+	}
+	// present but empty
+	r := httptest.NewRequest("GET", "/?name=", http.NoBody)
+	if err := Pick(&x, r); err == nil {
+		t.Error("expect error")
+	}
+	// missing
+	r = httptest.NewRequest("GET", "/?any=+", http.NoBody)
+	if err := Pick(&x, r); err != nil {
+		t.Error(err)
+	}
+	// only strings
+	var y struct {
+		N int `query:"n" notBlank:"true"`
+	}
+	r = httptest.NewRequest("GET", "/?n=1", http.NoBody)
+	if err := Pick(&y, r); err == nil {
+		t.Error("expect error")
+	}
+}
+
+func ExampleUseValidatorFor() {
+	type Money int
+	p := NewPicker()
+	UseValidatorFor(p, func(m Money) error {
+		if m < 0 {
+			return errors.New("negative")
+		}
+		return nil
+	})
+	var x struct {
+		Min Money `query:"min"`
+		Max Money `query:"max"`
+	}
+	r := httptest.NewRequest("GET", "/?min=0&max=-10", http.NoBody)
+	fmt.Println(p.Pick(&x, r))
+	// output:
+	// pick Max from query[max]: negative
+}
+
+func TestUseValidatorFor_duplicate(t *testing.T) {
+	defer catchPanic(t)
+	p := NewPicker()
+	fn := func(Color) error { return nil }
+	UseValidatorFor(p, fn)
+	UseValidatorFor(p, fn)
+}
+
+func TestUseValidatorFor_sameName(t *testing.T) {
+	p := NewPicker()
+	{
+		type ID string
+		UseValidatorFor(p, func(ID) error { return fmt.Errorf("invalid") })
+	}
+	type ID string
+	var x struct {
+		ID ID `query:"id"`
+	}
+	r := httptest.NewRequest("GET", "/?id=1", http.NoBody)
+	if err := p.Pick(&x, r); err != nil {
+		t.Error("validator of other type with same name used:", err)
+	}
+}
+
+func ExamplePicker_CheckValue() {
+	p := NewPicker()
+	err := p.CheckValue(int64(500), `minimum:"1" maximum:"100"`)
+	fmt.Println(err)
+	fmt.Println(ErrorCode(err))
+	// output:
+	// maximum 100: got 500
+	// maximum [100]
+}
@@ -0,0 +1,20 @@
+package xr
+
+import "net/http"
+
+// validateDest calls the Validate method of dst, if any, once all
+// fields are picked. Failures are returned as *PickError with source
+// validate.
+func validateDest(dst any, r *http.Request) error {
+	var err error
+	switch v := dst.(type) {
+	case interface{ Validate() error }:
+		err = v.Validate()
+	case interface{ Validate(*http.Request) error }:
+		err = v.Validate(r)
+	}
+	if err != nil {
+		return NewPickError(destName(dst), "validate", err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package xr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type Period struct {
+	From time.Time `query:"from" layout:"2006-01-02"`
+	To   time.Time `query:"to" layout:"2006-01-02"`
+}
+
+func (p *Period) Validate() error {
+	if p.To.Before(p.From) {
+		return errors.New("from after to")
+	}
+	return nil
+}
+
+func ExamplePick_validate() {
+	var x Period
+	r := httptest.NewRequest("GET", "/?from=2024-02-01&to=2024-01-01", nil)
+	fmt.Println(Pick(&x, r))
+	// output:
+	// pick Period from validate: from after to
+}
+
+type signed struct {
+	Sig string `header:"X-Sig"`
+}
+
+func (s *signed) Validate(r *http.Request) error {
+	if s.Sig != r.URL.Query().Get("sig") {
+		return errors.New("bad signature")
+	}
+	return nil
+}
+
+func Test_validateDest_request(t *testing.T) {
+	var x signed
+	r := httptest.NewRequest("GET", "/?sig=abc", http.NoBody)
+	r.Header.Set("X-Sig", "abc")
+	if err := Pick(&x, r); err != nil {
+		t.Error(err)
+	}
+	r.Header.Set("X-Sig", "xyz")
+	var e *PickError
+	if err := Pick(&x, r); !errors.As(err, &e) {
+		t.Error("expected *PickError, got", err)
+	}
+}
@@ -0,0 +1,53 @@
+package xr
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// RegisterValidator adds a named validation rule used by field tag
+// validate, e.g. `validate:"creditcard"`. Rules with an argument are
+// given as name=arg and several rules are separated by comma, e.g.
+// `validate:"prefix=SE,iban"`. fn is given the field value after
+// it's set. Safe to call while picking. Panics if name is already
+// registered.
+func (p *Picker) RegisterValidator(name string,
+	fn func(value any, arg string) error,
+) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, found := p.rules[name]; found {
+		panic(fmt.Sprintf("RegisterValidator(%q): already exists", name))
+	}
+	p.rules[name] = fn
+}
+
+// checkRules applies the validation rules of tag validate on field.
+func (p *Picker) checkRules(field reflect.Value, rules string) error {
+	if !field.CanInterface() {
+		return nil
+	}
+	for _, rule := range strings.Split(rules, ",") {
+		name, arg, _ := strings.Cut(rule, "=")
+		fn, found := lookup(p, p.rules, name)
+		if !found {
+			return fmt.Errorf("validate %s: unknown", name)
+		}
+		if err := fn(field.Interface(), arg); err != nil {
+			return fmt.Errorf("validate %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// checkRuleNames returns an error if any of the rules is unknown.
+func (p *Picker) checkRuleNames(rules string) error {
+	for _, rule := range strings.Split(rules, ",") {
+		name, _, _ := strings.Cut(rule, "=")
+		if _, found := lookup(p, p.rules, name); !found {
+			return fmt.Errorf("%s: unknown", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package xr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func ExamplePicker_RegisterValidator() {
+	p := NewPicker()
+	p.RegisterValidator("prefix", func(v any, arg string) error {
+		if !strings.HasPrefix(v.(string), arg) {
+			return errors.New("missing prefix " + arg)
+		}
+		return nil
+	})
+	var x struct {
+		Account string `query:"account" validate:"prefix=SE"`
+	}
+	r := httptest.NewRequest("GET", "/?account=SE123", http.NoBody)
+	fmt.Println(p.Pick(&x, r), x.Account)
+
+	r = httptest.NewRequest("GET", "/?account=NO123", http.NoBody)
+	fmt.Println(p.Pick(&x, r))
+	// output:
+	// <nil> SE123
+	// pick Account from query[account]: validate prefix: missing prefix SE
+}
+
+func TestPicker_RegisterValidator(t *testing.T) {
+	p := NewPicker()
+	p.RegisterValidator("even", func(v any, _ string) error {
+		if v.(int)%2 != 0 {
+			return errors.New("odd")
+		}
+		return nil
+	})
+	var x struct {
+		N int `query:"n" validate:"even,positive"`
+	}
+	r := httptest.NewRequest("GET", "/?n=2", http.NoBody)
+	if err := p.Pick(&x, r); err == nil {
+		t.Error("expected unknown validator error")
+	}
+	if err := p.Check(&x); err == nil {
+		t.Error("Check: expected error")
+	}
+	defer catchPanic(t)
+	p.RegisterValidator("even", nil)
+}